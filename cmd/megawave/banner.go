@@ -0,0 +1,52 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+)
+
+// binding describes a control listed in the banner
+type binding struct {
+	key  string
+	desc string
+}
+
+// bindings returns the controls the controller currently responds to
+func (c *controller) bindings() []binding {
+	start := binding{"Enter", "Start cooking"}
+	if c.confirmStart {
+		start.desc = "Start cooking (asks y/n)"
+	}
+
+	b := []binding{
+		{"0-9", "Enter time digits"},
+		{"Backspace", "Erase last digit"},
+		{"c", "Clear, stopping any cook"},
+		start,
+		{"Ctrl-C", "Stop cook, twice to exit"},
+	}
+	if len(c.microwaves) > 1 {
+		b = append(b,
+			binding{"Tab", "Next microwave"},
+			binding{"Alt+1-9", "Select microwave"},
+		)
+	}
+	return b
+}
+
+// banner renders the instructions box for the given controls
+func banner(bindings []binding) string {
+	var sb strings.Builder
+	sb.WriteString("╔════════════════════════════════════════╗\n")
+	sb.WriteString("║         MEGAWAVE MICROWAVE             ║\n")
+	sb.WriteString("╠════════════════════════════════════════╣\n")
+	sb.WriteString("║  Controls:                             ║\n")
+	for _, b := range bindings {
+		fmt.Fprintf(&sb, "║    %-10s: %-24s║\n", b.key, b.desc)
+	}
+	sb.WriteString("╠════════════════════════════════════════╣\n")
+	sb.WriteString("║  Display format: MM:SS                 ║\n")
+	sb.WriteString("║  Example: Press 1,3,5 for 01:35        ║\n")
+	sb.WriteString("╚════════════════════════════════════════╝\n")
+	return sb.String()
+}
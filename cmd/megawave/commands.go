@@ -0,0 +1,47 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+
+	"github.com/dskard/megawave/internal/factory"
+	"github.com/dskard/megawave/internal/telemetry"
+)
+
+// cookCmd cooks once for -duration without the interactive session,
+// printing each frame on its own line, and exits when the cook ends.
+// Ctrl-C cancels it.
+func cookCmd(ctx context.Context, _ context.CancelFunc, args []string) error {
+	fs := flag.NewFlagSet("megawave cook", flag.ContinueOnError)
+	duration := fs.Duration("duration", 0, "how long to cook, e.g. 90s or 1m30s (required)")
+
+	cfg, err := telemetry.ParseConfigArgs(fs, args)
+	if err != nil {
+		return fmt.Errorf("invalid configuration:\n%w", err)
+	}
+	if *duration <= 0 {
+		return fmt.Errorf("-duration must be positive, got %s", *duration)
+	}
+
+	m, shutdown, err := factory.NewMicrowave(ctx, cfg)
+	if err != nil {
+		return err
+	}
+	defer shutdown()
+
+	m.SetDuration(*duration)
+	m.PressStart(ctx)
+	return nil
+}
+
+// checkCmd validates the configuration from flags, env vars and the env
+// file without running anything, reporting every problem at once
+func checkCmd(_ context.Context, _ context.CancelFunc, args []string) error {
+	fs := flag.NewFlagSet("megawave check", flag.ContinueOnError)
+	if _, err := telemetry.ParseConfigArgs(fs, args); err != nil {
+		return fmt.Errorf("invalid configuration:\n%w", err)
+	}
+	fmt.Println("Configuration OK")
+	return nil
+}
@@ -0,0 +1,240 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/dskard/megawave/internal/microwave"
+)
+
+const (
+	keyCtrlC     = 3
+	keyCtrlH     = 0x08 // Backspace on some terminals
+	keyTab       = '\t'
+	keyEscape    = 0x1b
+	keyBackspace = 0x7f
+	keyClear     = 'c'
+)
+
+// csiDelete is the control sequence the Delete key sends after "ESC ["
+const csiDelete = "3~"
+
+// ctrlCWindow is how soon a second Ctrl-C must follow the one that stopped
+// a cook to exit the program
+const ctrlCWindow = 2 * time.Second
+
+// controller routes keypresses to the focused microwave
+type controller struct {
+	microwaves     []*microwave.Microwave
+	active         int
+	escPending     bool          // Escape was pressed, the next digit selects a microwave
+	csi            []byte        // Control sequence read so far after "ESC [" (nil when not in one)
+	confirmStart   bool          // Ask "Start? y/n" before cooking
+	confirmPending bool          // Waiting for the answer to "Start? y/n"
+	lastCtrlC      time.Time     // When Ctrl-C last stopped a cook (zero if never)
+	idleExit       time.Duration // Exit after this long without a keypress while idle (0 disables)
+	lastActive     time.Time     // Last keypress or moment spent cooking, for idleExit
+	drainCooks     bool          // Run cooks on drainCtx instead of the context they were started with
+
+	// cooks tracks cooks in progress, and drainCtx is what they run on with
+	// drainCooks set, so drain can wait for them and cancel any left over
+	cooks     sync.WaitGroup
+	drainCtx  context.Context
+	stopDrain context.CancelFunc
+
+	// start begins cooking on a microwave
+	start func(ctx context.Context, m *microwave.Microwave)
+
+	// now returns the current time, for double Ctrl-C and idle detection
+	now func() time.Time
+}
+
+// newController creates a controller focused on the first microwave.
+// Cooking runs in its own goroutine so other microwaves stay responsive.
+func newController(microwaves []*microwave.Microwave) *controller {
+	c := &controller{
+		microwaves: microwaves,
+		now:        time.Now,
+	}
+	c.drainCtx, c.stopDrain = context.WithCancel(context.Background())
+	c.start = func(ctx context.Context, m *microwave.Microwave) {
+		if c.drainCooks {
+			ctx = c.drainCtx
+		}
+		c.cooks.Add(1)
+		go func() {
+			defer c.cooks.Done()
+			m.PressStart(ctx)
+		}()
+	}
+	return c
+}
+
+// current returns the focused microwave
+func (c *controller) current() *microwave.Microwave {
+	return c.microwaves[c.active]
+}
+
+// focus switches the active microwave, ignoring out of range indexes
+func (c *controller) focus(i int) {
+	if i < 0 || i >= len(c.microwaves) || i == c.active {
+		return
+	}
+	c.active = i
+	fmt.Printf("Active microwave: %d/%d\r\n", c.active+1, len(c.microwaves))
+}
+
+// touch records activity, restarting the idle exit countdown
+func (c *controller) touch() {
+	c.lastActive = c.now()
+}
+
+// cooking reports whether any microwave is cooking
+func (c *controller) cooking() bool {
+	for _, m := range c.microwaves {
+		if m.IsCooking() {
+			return true
+		}
+	}
+	return false
+}
+
+// idleRemaining returns how long until the idle exit is due, or 0 once it
+// is. Time spent cooking counts as activity, so only idle time adds up.
+func (c *controller) idleRemaining() time.Duration {
+	if c.cooking() || c.lastActive.IsZero() {
+		c.touch()
+	}
+	return max(c.idleExit-c.now().Sub(c.lastActive), 0)
+}
+
+// drain waits for the cooks in progress to finish, up to timeout, and
+// returns true if they did. Cooks still running at the timeout are canceled.
+// Only useful with drainCooks set, since otherwise canceling the context
+// passed to handleKey already ended them.
+func (c *controller) drain(timeout time.Duration) bool {
+	done := make(chan struct{})
+	go func() {
+		c.cooks.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return true
+	case <-time.After(timeout):
+	}
+
+	c.stopDrain()
+	<-done
+	return false
+}
+
+// handleKey dispatches a single keypress. Returns true when the user asked to quit.
+func (c *controller) handleKey(ctx context.Context, key byte) bool {
+	c.touch()
+
+	if c.confirmPending && key != keyCtrlC {
+		c.confirmPending = false
+		if key == 'y' || key == 'Y' {
+			c.start(ctx, c.current())
+		} else {
+			fmt.Print("Start canceled\r\n")
+		}
+		return false
+	}
+
+	// Keys like Delete arrive as "ESC [" followed by parameters and a final
+	// byte. Read the whole sequence so its bytes aren't taken as digits.
+	if c.csi != nil {
+		if key >= 0x40 && key <= 0x7e {
+			if string(append(c.csi, key)) == csiDelete {
+				c.current().PressBackspace()
+			}
+			c.csi = nil
+		} else {
+			c.csi = append(c.csi, key)
+		}
+		return false
+	}
+
+	// Alt+digit arrives as Escape followed by the digit
+	if c.escPending {
+		c.escPending = false
+		if key >= '1' && key <= '9' {
+			c.focus(int(key - '1'))
+			return false
+		}
+		if key == '[' {
+			c.csi = []byte{}
+			return false
+		}
+	}
+
+	switch {
+	case key >= '0' && key <= '9':
+		// Digit pressed
+		c.current().PressDigit(int(key - '0'))
+
+	case key == '\r' || key == '\n':
+		// Enter pressed
+		if c.confirmStart {
+			c.confirmPending = true
+			fmt.Print("Start? y/n\r\n")
+		} else {
+			c.start(ctx, c.current())
+		}
+
+	case key == keyBackspace || key == keyCtrlH:
+		// Erase the last digit
+		c.current().PressBackspace()
+
+	case key == keyClear || key == 'C':
+		// Stop any cook and erase the digits
+		c.current().PressClear(ctx)
+
+	case key == keyTab:
+		// Cycle to the next microwave
+		c.focus((c.active + 1) % len(c.microwaves))
+
+	case key == keyEscape:
+		c.escPending = true
+
+	case key == keyCtrlC:
+		return c.handleCtrlC()
+	}
+
+	return false
+}
+
+// handleCtrlC stops every microwave's cook on the first press, like an
+// appliance's stop button, so quitting never leaves one cooking unseen. It
+// returns true, asking to quit, when nothing is cooking or when the press
+// follows a cook-stopping Ctrl-C within ctrlCWindow. A pending "Start? y/n"
+// is answered no either way.
+func (c *controller) handleCtrlC() bool {
+	if c.confirmPending {
+		c.confirmPending = false
+		fmt.Print("Start canceled\r\n")
+	}
+
+	now := c.now()
+	if !c.lastCtrlC.IsZero() && now.Sub(c.lastCtrlC) <= ctrlCWindow {
+		return true
+	}
+
+	stopped := false
+	for _, m := range c.microwaves {
+		if m.CancelCook() {
+			stopped = true
+		}
+	}
+	if !stopped {
+		return true
+	}
+	c.lastCtrlC = now
+	fmt.Print("Cooking stopped. Press Ctrl-C again to exit\r\n")
+	return false
+}
@@ -2,20 +2,38 @@ package main
 
 import (
 	"context"
+	"errors"
+	"flag"
 	"fmt"
 	"log"
 	"os"
 	"os/signal"
+	"strings"
 	"syscall"
 	"time"
 
-	"go.opentelemetry.io/otel"
 	"golang.org/x/term"
 
+	"github.com/dskard/megawave/internal/factory"
 	"github.com/dskard/megawave/internal/microwave"
 	"github.com/dskard/megawave/internal/telemetry"
 )
 
+// shutdownTimeout bounds each step of shutting down: draining cooks and
+// flushing telemetry
+const shutdownTimeout = 5 * time.Second
+
+// subcommands maps each subcommand to the function that runs it with the
+// arguments after its name
+var subcommands = map[string]func(ctx context.Context, cancel context.CancelFunc, args []string) error{
+	"run":   runCmd,
+	"cook":  cookCmd,
+	"check": checkCmd,
+}
+
+// subcommandNames lists the subcommands in the order they are documented
+var subcommandNames = []string{"run", "cook", "check"}
+
 func main() {
 	ctx, cancel := signal.NotifyContext(context.Background(),
 		os.Interrupt,    // Ctrl-C
@@ -23,67 +41,119 @@ func main() {
 	)
 	defer cancel()
 
+	name, args, err := splitSubcommand(os.Args[1:])
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "megawave: %v\n", err)
+		os.Exit(2)
+	}
+	if err := subcommands[name](ctx, cancel, args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return
+		}
+		log.Fatal(err)
+	}
+}
+
+// splitSubcommand picks the subcommand out of the command line arguments,
+// returning its name and the arguments that follow it. With no subcommand,
+// including when the arguments start with a flag, it returns "run" so bare
+// "megawave -instances=2" keeps working.
+func splitSubcommand(args []string) (string, []string, error) {
+	if len(args) == 0 || strings.HasPrefix(args[0], "-") {
+		return "run", args, nil
+	}
+	if _, ok := subcommands[args[0]]; !ok {
+		return "", nil, fmt.Errorf("unknown subcommand %q: expected %s", args[0], strings.Join(subcommandNames, ", "))
+	}
+	return args[0], args[1:], nil
+}
+
+// runCmd runs the interactive session, the default subcommand
+func runCmd(ctx context.Context, cancel context.CancelFunc, args []string) error {
+	fs := flag.NewFlagSet("megawave run", flag.ContinueOnError)
+	instances := fs.Int("instances", 1, "number of microwaves to simulate")
+	confirmStart := fs.Bool("confirm-start", false, "ask for confirmation before cooking starts")
+	noBanner := fs.Bool("no-banner", false, "do not print the instructions banner")
+	idleExit := fs.Duration("idle-exit", 0, "exit after this long without a keypress while nothing is cooking (0 disables)")
+	drainOnShutdown := fs.Bool("drain-on-shutdown", false,
+		fmt.Sprintf("on shutdown, stop taking input but let cooks in progress finish (up to %s)", shutdownTimeout))
+	pprofAddr := fs.String("pprof-addr", "", "serve net/http/pprof on this address, e.g. localhost:6060 (empty disables)")
+
 	// Parse config (flags override env vars)
-	cfg := telemetry.ParseConfig()
+	cfg, err := telemetry.ParseConfigArgs(fs, args)
+	if err != nil {
+		return fmt.Errorf("invalid configuration:\n%w", err)
+	}
+	if *instances < 1 {
+		return fmt.Errorf("-instances must be at least 1, got %d", *instances)
+	}
+	if *idleExit < 0 {
+		return fmt.Errorf("-idle-exit must not be negative, got %s", *idleExit)
+	}
 
-	// Initialize OTel if in production
-	var otelShutdown func(context.Context) error
-	if cfg.Environment == telemetry.Production {
-		var err error
-		otelShutdown, err = telemetry.InitOTel(ctx, cfg)
+	logger, shutdown, err := telemetry.Setup(ctx, cfg)
+	if err != nil {
+		return err
+	}
+	defer shutdown()
+
+	// Serve profiles for the life of the program
+	if *pprofAddr != "" {
+		_, stopPprof, err := startPprof(*pprofAddr, logger)
 		if err != nil {
-			log.Fatal(err)
+			return fmt.Errorf("failed to start pprof server: %w", err)
 		}
-		// Shutdown with fresh context (not the canceled one) to allow flushing
 		defer func() {
-			shutdownCtx, shutdownCancel := context.WithTimeout(context.Background(), 5*time.Second)
+			shutdownCtx, shutdownCancel := context.WithTimeout(context.Background(), shutdownTimeout)
 			defer shutdownCancel()
-			_ = otelShutdown(shutdownCtx)
+			_ = stopPprof(shutdownCtx)
 		}()
 	}
 
-	// Create logger based on config (returns cleanup function for file handle)
-	logger, closeLog := telemetry.NewLogger(cfg)
-	defer func() { _ = closeLog() }()
+	// Create microwaves, tagging each one's logs with its instance number
+	microwaves := make([]*microwave.Microwave, *instances)
+	for i := range microwaves {
+		microwaves[i] = microwave.New(factory.MicrowaveOptions(logger.With("instance", i+1))...)
+	}
 
-	// Create microwave
-	m := microwave.New(
-		microwave.WithLogger(logger),
-		microwave.WithTracer(otel.Tracer("megawave")),
-		microwave.WithMeter(otel.Meter("megawave")),
-	)
+	c := newController(microwaves)
+	c.confirmStart = *confirmStart
+	c.idleExit = *idleExit
+	c.drainCooks = *drainOnShutdown
 
 	// Print instructions
-	printInstructions()
+	if !*noBanner {
+		printInstructions(c)
+	}
 
 	// Run interactive loop
-	if err := runInteractive(ctx, cancel, m); err != nil {
-		if err != context.Canceled {
-			log.Fatal(err)
+	if err := runInteractive(ctx, cancel, c); err != nil && err != context.Canceled {
+		return err
+	}
+
+	// Input has stopped; let cooks in progress finish before exiting
+	if c.drainCooks && c.cooking() {
+		fmt.Println("\nWaiting for cooking to finish...")
+		if !c.drain(shutdownTimeout) {
+			logger.Warn("cooks still running at shutdown were canceled", "timeout", shutdownTimeout)
 		}
 	}
 
 	fmt.Println("\nGoodbye!")
+	return nil
 }
 
-func printInstructions() {
-	fmt.Println("╔════════════════════════════════════════╗")
-	fmt.Println("║         MEGAWAVE MICROWAVE             ║")
-	fmt.Println("╠════════════════════════════════════════╣")
-	fmt.Println("║  Controls:                             ║")
-	fmt.Println("║    0-9       : Enter time digits       ║")
-	fmt.Println("║    Enter     : Start cooking           ║")
-	fmt.Println("║    Ctrl-C    : Exit                    ║")
-	fmt.Println("╠════════════════════════════════════════╣")
-	fmt.Println("║  Display format: MM:SS                 ║")
-	fmt.Println("║  Example: Press 1,3,5 for 01:35        ║")
-	fmt.Println("╚════════════════════════════════════════╝")
+func printInstructions(c *controller) {
+	fmt.Print(banner(c.bindings()))
 	fmt.Println()
 	fmt.Println("Ready. Enter time and press Enter to start.")
+	if len(c.microwaves) > 1 {
+		fmt.Printf("Active microwave: %d/%d\n", c.active+1, len(c.microwaves))
+	}
 	fmt.Println()
 }
 
-func runInteractive(ctx context.Context, cancel context.CancelFunc, m *microwave.Microwave) error {
+func runInteractive(ctx context.Context, cancel context.CancelFunc, c *controller) error {
 	// Set terminal to raw mode to capture individual keypresses
 	oldState, err := term.MakeRaw(int(os.Stdin.Fd()))
 	if err != nil {
@@ -95,6 +165,13 @@ func runInteractive(ctx context.Context, cancel context.CancelFunc, m *microwave
 	keyChan := make(chan byte, 1)
 	errChan := make(chan error, 1)
 
+	// Print status on request without interrupting the session
+	statusChan := make(chan os.Signal, 1)
+	if len(statusSignals) > 0 {
+		signal.Notify(statusChan, statusSignals...)
+		defer signal.Stop(statusChan)
+	}
+
 	// Start goroutine to read keypresses
 	go func() {
 		buf := make([]byte, 1)
@@ -105,34 +182,49 @@ func runInteractive(ctx context.Context, cancel context.CancelFunc, m *microwave
 				return
 			}
 			if n > 0 {
-				// In raw mode, Ctrl-C doesn't generate SIGINT, so we
-				// cancel the context here to allow immediate interruption
-				if buf[0] == 3 { // Ctrl-C
-					cancel()
-				}
 				keyChan <- buf[0]
 			}
 		}
 	}()
 
+	// Exit when no key is pressed for -idle-exit while nothing is cooking.
+	// The timer restarts on each keypress and is re-armed while cooking.
+	var idle *time.Timer
+	var idleChan <-chan time.Time
+	if c.idleExit > 0 {
+		c.touch()
+		idle = time.NewTimer(c.idleExit)
+		defer idle.Stop()
+		idleChan = idle.C
+	}
+
 	for {
 		select {
 		case <-ctx.Done():
 			return ctx.Err()
 		case err := <-errChan:
 			return err
+		case <-idleChan:
+			wait := c.idleRemaining()
+			if wait > 0 {
+				idle.Reset(wait)
+				continue
+			}
+			fmt.Printf("No input for %s, exiting\r\n", c.idleExit)
+			cancel()
+			return context.Canceled
+		case <-statusChan:
+			if err := printStatus(os.Stdout, c.microwaves); err != nil {
+				c.current().Logger().Warn("failed to print status", "error", err)
+			}
 		case char := <-keyChan:
-			switch {
-			case char >= '0' && char <= '9':
-				// Digit pressed
-				digit := int(char - '0')
-				m.PressDigit(digit)
-
-			case char == '\r' || char == '\n':
-				// Enter pressed
-				m.PressStart(ctx)
-
-			case char == 3: // Ctrl-C
+			if idle != nil {
+				idle.Reset(c.idleExit)
+			}
+			// In raw mode, Ctrl-C doesn't generate SIGINT, so the
+			// controller decides when it means exit and we cancel here
+			if c.handleKey(ctx, char) {
+				cancel()
 				return context.Canceled
 			}
 		}
@@ -1,7 +1,668 @@
 package main
 
-import "testing"
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+	"unicode/utf8"
+
+	"github.com/dskard/megawave/internal/microwave"
+)
 
 func TestMain(t *testing.T) {
 	// Add your tests here
 }
+
+// newTestMicrowaves creates n microwaves with default options
+func newTestMicrowaves(n int) []*microwave.Microwave {
+	microwaves := make([]*microwave.Microwave, n)
+	for i := range microwaves {
+		microwaves[i] = microwave.New()
+	}
+	return microwaves
+}
+
+// recordStarts replaces the controller's start action with one that records
+// which microwave was started instead of cooking
+func recordStarts(c *controller) *[]*microwave.Microwave {
+	var started []*microwave.Microwave
+	c.start = func(_ context.Context, m *microwave.Microwave) {
+		started = append(started, m)
+	}
+	return &started
+}
+
+// handleKey Test Cases
+
+// TestHandleKeyTabCyclesFocus verifies that Tab cycles the active microwave and wraps around.
+// Test logic: Creates a controller with 3 microwaves, presses Tab repeatedly, and checks
+// the active index after each press.
+func TestHandleKeyTabCyclesFocus(t *testing.T) {
+	c := newController(newTestMicrowaves(3))
+
+	for _, want := range []int{1, 2, 0} {
+		// Press Tab to move focus
+		c.handleKey(context.Background(), keyTab)
+		if c.active != want {
+			t.Errorf("active = %d, want %d", c.active, want)
+		}
+	}
+}
+
+// TestHandleKeyEscapeDigitSelectsMicrowave verifies that Alt+digit focuses a specific microwave.
+// Test logic: Sends Escape followed by '3' and checks the third microwave is focused, then
+// sends Escape followed by '9' (out of range) and checks focus is unchanged.
+func TestHandleKeyEscapeDigitSelectsMicrowave(t *testing.T) {
+	c := newController(newTestMicrowaves(3))
+	ctx := context.Background()
+
+	// Alt+3 selects the third microwave
+	c.handleKey(ctx, keyEscape)
+	c.handleKey(ctx, '3')
+	if c.active != 2 {
+		t.Errorf("active = %d, want 2", c.active)
+	}
+
+	// Alt+9 is out of range and is ignored
+	c.handleKey(ctx, keyEscape)
+	c.handleKey(ctx, '9')
+	if c.active != 2 {
+		t.Errorf("active = %d, want 2 after out of range selection", c.active)
+	}
+
+	// The selection digit must not be entered as a cooking time
+	for i, m := range c.microwaves {
+		if got := m.Display(); got != "00:00" {
+			t.Errorf("microwave %d Display() = %s, want 00:00", i+1, got)
+		}
+	}
+}
+
+// TestHandleKeyDigitsGoToFocusedMicrowave verifies that digits only affect the focused microwave.
+// Test logic: Presses '5', switches focus with Tab, presses '7', then checks each
+// microwave's display shows only the digit entered while it was focused.
+func TestHandleKeyDigitsGoToFocusedMicrowave(t *testing.T) {
+	c := newController(newTestMicrowaves(2))
+	ctx := context.Background()
+
+	c.handleKey(ctx, '5')
+	c.handleKey(ctx, keyTab)
+	c.handleKey(ctx, '7')
+
+	if got := c.microwaves[0].Display(); got != "00:05" {
+		t.Errorf("microwave 1 Display() = %s, want 00:05", got)
+	}
+	if got := c.microwaves[1].Display(); got != "00:07" {
+		t.Errorf("microwave 2 Display() = %s, want 00:07", got)
+	}
+}
+
+// TestHandleKeyBackspace verifies that Backspace, Ctrl-H and Delete erase the last digit.
+// Test logic: Enters 1,2,3 and erases one digit each with Backspace (0x7f), Ctrl-H (0x08)
+// and the Delete sequence ESC [ 3 ~, checking the display after each. The '3' inside the
+// Delete sequence must not be entered as a digit.
+func TestHandleKeyBackspace(t *testing.T) {
+	c := newController(newTestMicrowaves(1))
+	ctx := context.Background()
+	for _, key := range []byte("123") {
+		c.handleKey(ctx, key)
+	}
+
+	steps := []struct {
+		name string
+		keys []byte
+		want string
+	}{
+		{"Backspace", []byte{keyBackspace}, "00:12"},
+		{"Ctrl-H", []byte{keyCtrlH}, "00:01"},
+		{"Delete", []byte{keyEscape, '[', '3', '~'}, "00:00"},
+	}
+	for _, step := range steps {
+		for _, key := range step.keys {
+			c.handleKey(ctx, key)
+		}
+		if got := c.current().Display(); got != step.want {
+			t.Errorf("after %s Display() = %s, want %s", step.name, got, step.want)
+		}
+	}
+}
+
+// TestHandleKeyClear verifies that c and C clear the focused microwave.
+// Test logic: Enters digits and presses c, then enters more and presses C, checking the
+// display returns to 00:00 each time.
+func TestHandleKeyClear(t *testing.T) {
+	c := newController(newTestMicrowaves(1))
+	ctx := context.Background()
+
+	for _, clear := range []byte{'c', 'C'} {
+		for _, key := range []byte("123") {
+			c.handleKey(ctx, key)
+		}
+		c.handleKey(ctx, clear)
+		if got := c.current().Display(); got != "00:00" {
+			t.Errorf("after %q Display() = %s, want 00:00", clear, got)
+		}
+	}
+}
+
+// TestHandleKeyIgnoresOtherEscapeSequences verifies that unhandled escape sequences are consumed.
+// Test logic: Sends the Up arrow (ESC [ A) and Page Up (ESC [ 5 ~) sequences and checks
+// none of their bytes were entered as digits, then checks a following digit still works.
+func TestHandleKeyIgnoresOtherEscapeSequences(t *testing.T) {
+	c := newController(newTestMicrowaves(1))
+	ctx := context.Background()
+
+	for _, key := range []byte{keyEscape, '[', 'A', keyEscape, '[', '5', '~'} {
+		c.handleKey(ctx, key)
+	}
+	if got := c.current().Display(); got != "00:00" {
+		t.Errorf("Display() = %s after escape sequences, want 00:00", got)
+	}
+
+	// Input is back to normal once the sequence ends
+	c.handleKey(ctx, '7')
+	if got := c.current().Display(); got != "00:07" {
+		t.Errorf("Display() = %s, want 00:07", got)
+	}
+}
+
+// TestHandleKeyCtrlCQuits verifies that Ctrl-C while idle asks the loop to quit and other keys do not.
+// Test logic: Sends a digit and Ctrl-C and checks the returned quit flag for each.
+func TestHandleKeyCtrlCQuits(t *testing.T) {
+	c := newController(newTestMicrowaves(1))
+	ctx := context.Background()
+
+	if c.handleKey(ctx, '1') {
+		t.Error("handleKey('1') should not quit")
+	}
+	if !c.handleKey(ctx, keyCtrlC) {
+		t.Error("handleKey(Ctrl-C) should quit")
+	}
+}
+
+// TestHandleKeyEnterStarts verifies that Enter starts the focused microwave immediately
+// when confirmation is off.
+// Test logic: Records start calls, presses Enter, and checks the focused microwave started.
+func TestHandleKeyEnterStarts(t *testing.T) {
+	c := newController(newTestMicrowaves(1))
+	started := recordStarts(c)
+
+	c.handleKey(context.Background(), '\r')
+
+	if len(*started) != 1 || (*started)[0] != c.microwaves[0] {
+		t.Errorf("expected the focused microwave to start, got %d starts", len(*started))
+	}
+}
+
+// TestHandleKeyConfirmStart verifies the "Start? y/n" confirmation when -confirm-start is set.
+// Test logic: With confirmation on, presses Enter then 'n' and checks nothing started,
+// then presses Enter then 'y' and checks the focused microwave started.
+func TestHandleKeyConfirmStart(t *testing.T) {
+	c := newController(newTestMicrowaves(1))
+	c.confirmStart = true
+	started := recordStarts(c)
+	ctx := context.Background()
+
+	// Enter asks for confirmation without starting
+	c.handleKey(ctx, '\r')
+	if len(*started) != 0 {
+		t.Fatal("Enter should not start cooking before confirmation")
+	}
+
+	// 'n' aborts the start
+	c.handleKey(ctx, 'n')
+	if len(*started) != 0 {
+		t.Error("'n' should abort the start")
+	}
+
+	// 'y' confirms the start
+	c.handleKey(ctx, '\r')
+	c.handleKey(ctx, 'y')
+	if len(*started) != 1 {
+		t.Errorf("'y' should start cooking, got %d starts", len(*started))
+	}
+}
+
+// TestHandleKeyConfirmStartAnswerIsNotADigit verifies that the confirmation answer is consumed.
+// Test logic: With confirmation on, presses Enter then '5' (not 'y') and checks the
+// start was aborted and '5' was not entered as a digit.
+func TestHandleKeyConfirmStartAnswerIsNotADigit(t *testing.T) {
+	c := newController(newTestMicrowaves(1))
+	c.confirmStart = true
+	started := recordStarts(c)
+	ctx := context.Background()
+
+	c.handleKey(ctx, '\r')
+	c.handleKey(ctx, '5')
+
+	if len(*started) != 0 {
+		t.Error("a non-'y' answer should abort the start")
+	}
+	if got := c.microwaves[0].Display(); got != "00:00" {
+		t.Errorf("Display() = %s, want 00:00", got)
+	}
+}
+
+// startCooking starts a long cook on m and waits until it is running. The
+// returned channel is closed when the cook ends.
+func startCooking(t *testing.T, m *microwave.Microwave) <-chan struct{} {
+	t.Helper()
+
+	m.SetDuration(time.Minute)
+	done := make(chan struct{})
+	go func() {
+		m.PressStart(context.Background())
+		close(done)
+	}()
+
+	deadline := time.Now().Add(5 * time.Second)
+	for !m.IsCooking() {
+		if time.Now().After(deadline) {
+			t.Fatal("timed out waiting for cooking to start")
+		}
+		time.Sleep(time.Millisecond)
+	}
+	return done
+}
+
+// TestHandleKeyDoubleCtrlC verifies that Ctrl-C stops a cook first and exits on a quick second press.
+// Test logic: With a fake time source, starts a cook and presses Ctrl-C, checking it stops
+// the cook without quitting. Then presses Ctrl-C again 1s later and checks it quits.
+func TestHandleKeyDoubleCtrlC(t *testing.T) {
+	m := microwave.New(microwave.WithOutput(io.Discard))
+	c := newController([]*microwave.Microwave{m})
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	c.now = func() time.Time { return now }
+	ctx := context.Background()
+
+	// First Ctrl-C stops the cook and keeps running
+	done := startCooking(t, m)
+	if c.handleKey(ctx, keyCtrlC) {
+		t.Fatal("first Ctrl-C during a cook should not quit")
+	}
+	<-done
+
+	// A second Ctrl-C within the window quits
+	now = now.Add(1 * time.Second)
+	if !c.handleKey(ctx, keyCtrlC) {
+		t.Error("second Ctrl-C within the window should quit")
+	}
+}
+
+// TestHandleKeyCtrlCWindowExpires verifies that a Ctrl-C after the window is treated as a first press.
+// Test logic: Stops one cook with Ctrl-C, advances the fake time past the window, starts
+// another cook and presses Ctrl-C, and checks it stops that cook instead of quitting.
+func TestHandleKeyCtrlCWindowExpires(t *testing.T) {
+	m := microwave.New(microwave.WithOutput(io.Discard))
+	c := newController([]*microwave.Microwave{m})
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	c.now = func() time.Time { return now }
+	ctx := context.Background()
+
+	done := startCooking(t, m)
+	c.handleKey(ctx, keyCtrlC)
+	<-done
+
+	// Past the window, Ctrl-C during a new cook stops it again
+	now = now.Add(ctrlCWindow + time.Second)
+	done = startCooking(t, m)
+	if c.handleKey(ctx, keyCtrlC) {
+		t.Error("Ctrl-C after the window should stop the cook, not quit")
+	}
+	<-done
+}
+
+// TestHandleKeyCtrlCStopsEveryMicrowave verifies that Ctrl-C stops cooks on microwaves that aren't focused.
+// Test logic: With two microwaves, starts a cook on the second while the first is focused and
+// idle, presses Ctrl-C, and checks it stops that cook instead of quitting.
+func TestHandleKeyCtrlCStopsEveryMicrowave(t *testing.T) {
+	microwaves := []*microwave.Microwave{
+		microwave.New(microwave.WithOutput(io.Discard)),
+		microwave.New(microwave.WithOutput(io.Discard)),
+	}
+	c := newController(microwaves)
+
+	done := startCooking(t, microwaves[1])
+	if c.handleKey(context.Background(), keyCtrlC) {
+		t.Fatal("Ctrl-C quit while the unfocused microwave was cooking")
+	}
+	<-done
+	if microwaves[1].IsCooking() {
+		t.Error("unfocused microwave still cooking after Ctrl-C")
+	}
+}
+
+// TestHandleKeyCtrlCClearsConfirm verifies that Ctrl-C at the "Start? y/n" prompt answers it no.
+// Test logic: With confirmation on and a cook running, presses Enter to open the prompt, then
+// Ctrl-C, and checks the cook stopped without quitting and the prompt was cleared, so a later
+// 'y' is not taken as confirmation.
+func TestHandleKeyCtrlCClearsConfirm(t *testing.T) {
+	m := microwave.New(microwave.WithOutput(io.Discard))
+	c := newController([]*microwave.Microwave{m})
+	started := recordStarts(c)
+	c.confirmStart = true
+	ctx := context.Background()
+
+	done := startCooking(t, m)
+	c.handleKey(ctx, '\r')
+	if c.handleKey(ctx, keyCtrlC) {
+		t.Fatal("Ctrl-C during a cook should not quit")
+	}
+	<-done
+	if c.confirmPending {
+		t.Error("confirmation still pending after Ctrl-C")
+	}
+
+	// 'y' is no longer an answer
+	c.handleKey(ctx, 'y')
+	if len(*started) != 0 {
+		t.Errorf("started %d cooks after the prompt was canceled, want 0", len(*started))
+	}
+}
+
+// TestIdleExitOnlyWhileIdle verifies that the idle exit comes due only after idle time without keypresses.
+// Test logic: With a fake time source and a 1m idle exit, checks a keypress restarts the
+// countdown, that time spent cooking never counts, and that the exit comes due a full
+// minute after the cook ends.
+func TestIdleExitOnlyWhileIdle(t *testing.T) {
+	m := microwave.New(microwave.WithOutput(io.Discard))
+	c := newController([]*microwave.Microwave{m})
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	c.now = func() time.Time { return now }
+	c.idleExit = time.Minute
+	ctx := context.Background()
+
+	// A keypress starts the countdown
+	c.handleKey(ctx, '1')
+	now = now.Add(40 * time.Second)
+	if got := c.idleRemaining(); got != 20*time.Second {
+		t.Errorf("idleRemaining() = %s after 40s, want 20s", got)
+	}
+
+	// Another keypress restarts it
+	c.handleKey(ctx, '2')
+	now = now.Add(40 * time.Second)
+	if got := c.idleRemaining(); got != 20*time.Second {
+		t.Errorf("idleRemaining() = %s 40s after a keypress, want 20s", got)
+	}
+
+	// Time spent cooking is not idle
+	done := startCooking(t, m)
+	now = now.Add(5 * time.Minute)
+	if got := c.idleRemaining(); got != time.Minute {
+		t.Errorf("idleRemaining() = %s while cooking, want 1m", got)
+	}
+	m.CancelCook()
+	<-done
+
+	// Idle time counts from the cook ending
+	now = now.Add(59 * time.Second)
+	if got := c.idleRemaining(); got != time.Second {
+		t.Errorf("idleRemaining() = %s 59s after cooking, want 1s", got)
+	}
+	now = now.Add(time.Second)
+	if got := c.idleRemaining(); got != 0 {
+		t.Errorf("idleRemaining() = %s after a minute idle, want 0", got)
+	}
+}
+
+// banner Test Cases
+
+// TestBannerReflectsBindings verifies that the banner lists the controller's controls.
+// Test logic: Renders the banner for a single microwave and for three microwaves with
+// confirmation on, and checks the multi-instance and confirmation controls only appear
+// when they are active.
+func TestBannerReflectsBindings(t *testing.T) {
+	// Single microwave, no confirmation
+	single := banner(newController(newTestMicrowaves(1)).bindings())
+	if strings.Contains(single, "Tab") {
+		t.Error("single microwave banner should not list Tab")
+	}
+	if strings.Contains(single, "asks y/n") {
+		t.Error("banner should not mention confirmation when it is off")
+	}
+
+	// Multiple microwaves with confirmation
+	c := newController(newTestMicrowaves(3))
+	c.confirmStart = true
+	multi := banner(c.bindings())
+	for _, want := range []string{"Tab", "Next microwave", "Alt+1-9", "Start cooking (asks y/n)"} {
+		if !strings.Contains(multi, want) {
+			t.Errorf("banner missing %q:\n%s", want, multi)
+		}
+	}
+}
+
+// TestBannerLinesAligned verifies that every banner line has the same width.
+// Test logic: Renders a banner with all optional controls and checks each line has
+// the same number of characters so the box borders line up.
+func TestBannerLinesAligned(t *testing.T) {
+	c := newController(newTestMicrowaves(2))
+	c.confirmStart = true
+
+	lines := strings.Split(strings.TrimSuffix(banner(c.bindings()), "\n"), "\n")
+	width := utf8.RuneCountInString(lines[0])
+	for _, line := range lines {
+		if got := utf8.RuneCountInString(line); got != width {
+			t.Errorf("line %q has width %d, want %d", line, got, width)
+		}
+	}
+}
+
+// printStatus Test Cases
+
+// TestPrintStatus verifies that each microwave's status is written as a JSON line.
+// Test logic: Enters 1,3,0 on the second of two microwaves, prints their status, and checks
+// there is one valid JSON object per line ending in \r\n, each with the expected fields
+// and values.
+func TestPrintStatus(t *testing.T) {
+	microwaves := []*microwave.Microwave{
+		microwave.New(microwave.WithOutput(io.Discard)),
+		microwave.New(microwave.WithOutput(io.Discard)),
+	}
+	for _, d := range []int{1, 3, 0} {
+		microwaves[1].PressDigit(d)
+	}
+
+	var buf bytes.Buffer
+	if err := printStatus(&buf, microwaves); err != nil {
+		t.Fatalf("printStatus() error = %v", err)
+	}
+
+	lines := strings.Split(strings.TrimSuffix(buf.String(), "\r\n"), "\r\n")
+	if len(lines) != 2 {
+		t.Fatalf("expected 2 lines, got %d: %q", len(lines), buf.String())
+	}
+	wantDisplays := []string{"00:00", "01:30"}
+	for i, line := range lines {
+		var got map[string]any
+		if err := json.Unmarshal([]byte(line), &got); err != nil {
+			t.Fatalf("line %d is not valid JSON: %v", i+1, err)
+		}
+		for _, field := range []string{"instance", "display", "cooking", "digit_count", "elapsed_seconds", "remaining_seconds"} {
+			if _, ok := got[field]; !ok {
+				t.Errorf("line %d missing field %q", i+1, field)
+			}
+		}
+		if got["instance"] != float64(i+1) || got["display"] != wantDisplays[i] || got["cooking"] != false {
+			t.Errorf("line %d = %v, want instance %d idle at %s", i+1, got, i+1, wantDisplays[i])
+		}
+	}
+}
+
+// drain Test Cases
+
+// TestDrainLetsCookFinish verifies that with drainCooks set, a cook outlives its canceled context.
+// Test logic: Starts a 00:03 cook with a 10ms logical second through the controller, cancels the
+// context it was started with as a shutdown would, and checks drain reports the cook finished
+// and the cook ended as completed.
+func TestDrainLetsCookFinish(t *testing.T) {
+	m := microwave.New(microwave.WithOutput(io.Discard), microwave.WithLogicalSecond(10*time.Millisecond))
+	c := newController([]*microwave.Microwave{m})
+	c.drainCooks = true
+
+	ctx, cancel := context.WithCancel(context.Background())
+	m.SetDuration(3 * time.Second)
+	c.start(ctx, m)
+	cancel()
+
+	if !c.drain(time.Second) {
+		t.Fatal("drain() = false, want the cook to finish before the timeout")
+	}
+	if result, ok := m.LastCook(); !ok || !result.Completed {
+		t.Errorf("LastCook() = %+v, %v; want a completed cook", result, ok)
+	}
+}
+
+// TestDrainTimeoutCancelsCook verifies that cooks still running when the drain times out are canceled.
+// Test logic: Starts a 99:99 cook through a draining controller, drains with a 10ms timeout, and
+// checks drain reports the timeout, the cook ended as canceled, and nothing is cooking.
+func TestDrainTimeoutCancelsCook(t *testing.T) {
+	m := microwave.New(microwave.WithOutput(io.Discard))
+	c := newController([]*microwave.Microwave{m})
+	c.drainCooks = true
+
+	m.SetDuration(m.MaxDuration())
+	c.start(context.Background(), m)
+
+	if c.drain(10 * time.Millisecond) {
+		t.Fatal("drain() = true, want the timeout to be reported")
+	}
+	if result, ok := m.LastCook(); !ok || result.Completed {
+		t.Errorf("LastCook() = %+v, %v; want a canceled cook", result, ok)
+	}
+	if c.cooking() {
+		t.Error("still cooking after drain timed out")
+	}
+}
+
+// startPprof Test Cases
+
+// TestStartPprofServes verifies that the pprof server serves profiles and stops on shutdown.
+// Test logic: Starts the server on an ephemeral port, fetches the profile index and checks
+// it lists the heap profile, then shuts the server down and checks the port refuses requests.
+func TestStartPprofServes(t *testing.T) {
+	addr, stop, err := startPprof("127.0.0.1:0", slog.New(slog.NewTextHandler(io.Discard, nil)))
+	if err != nil {
+		t.Fatalf("startPprof() error = %v", err)
+	}
+	url := "http://" + addr + "/debug/pprof/"
+
+	// The index lists the available profiles
+	resp, err := http.Get(url)
+	if err != nil {
+		t.Fatalf("GET %s error = %v", url, err)
+	}
+	body, _ := io.ReadAll(resp.Body)
+	_ = resp.Body.Close()
+	if resp.StatusCode != http.StatusOK || !strings.Contains(string(body), "heap") {
+		t.Fatalf("GET %s = %d %q, want 200 listing the heap profile", url, resp.StatusCode, body)
+	}
+
+	// After shutdown nothing is listening
+	if err := stop(context.Background()); err != nil {
+		t.Fatalf("shutdown error = %v", err)
+	}
+	client := &http.Client{Transport: &http.Transport{DisableKeepAlives: true}}
+	if resp, err := client.Get(url); err == nil {
+		_ = resp.Body.Close()
+		t.Error("GET after shutdown succeeded, want connection error")
+	}
+}
+
+// TestStartPprofInvalidAddr verifies that a bad address is reported instead of failing later.
+// Test logic: Starts the server on an address with no port and checks for an error.
+func TestStartPprofInvalidAddr(t *testing.T) {
+	if _, _, err := startPprof("localhost", slog.New(slog.NewTextHandler(io.Discard, nil))); err == nil {
+		t.Error("startPprof(\"localhost\") error = nil, want error")
+	}
+}
+
+// splitSubcommand Test Cases
+
+// TestSplitSubcommand verifies that the subcommand is picked out and bare flags default to run.
+// Test logic: Uses table-driven tests to split argument lists with and without a subcommand,
+// and checks the name and the arguments left for the subcommand's flags.
+func TestSplitSubcommand(t *testing.T) {
+	tests := []struct {
+		name     string
+		args     []string
+		wantName string
+		wantArgs []string
+	}{
+		{"no arguments", nil, "run", nil},
+		{"flags only", []string{"-instances=2"}, "run", []string{"-instances=2"}},
+		{"run", []string{"run", "-no-banner"}, "run", []string{"-no-banner"}},
+		{"cook", []string{"cook", "-duration=90s"}, "cook", []string{"-duration=90s"}},
+		{"check", []string{"check"}, "check", []string{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			name, args, err := splitSubcommand(tt.args)
+			if err != nil {
+				t.Fatalf("splitSubcommand(%q) error = %v", tt.args, err)
+			}
+			if name != tt.wantName {
+				t.Errorf("name = %q, want %q", name, tt.wantName)
+			}
+			if strings.Join(args, " ") != strings.Join(tt.wantArgs, " ") {
+				t.Errorf("args = %q, want %q", args, tt.wantArgs)
+			}
+		})
+	}
+}
+
+// TestSplitSubcommandUnknown verifies that an unknown subcommand is an error naming it.
+// Test logic: Splits "bake -duration=1m" and checks the error mentions "bake" and lists
+// the subcommands that do exist.
+func TestSplitSubcommandUnknown(t *testing.T) {
+	_, _, err := splitSubcommand([]string{"bake", "-duration=1m"})
+	if err == nil {
+		t.Fatal("splitSubcommand(bake) error = nil, want error")
+	}
+	for _, want := range []string{`"bake"`, "run", "cook", "check"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("error %q does not mention %s", err, want)
+		}
+	}
+}
+
+// cookCmd Test Cases
+
+// TestCookCmdRequiresDuration verifies that cook refuses a missing or non-positive duration.
+// Test logic: Runs cook with no -duration and with a negative one, and checks each
+// returns an error naming the flag before anything is cooked.
+func TestCookCmdRequiresDuration(t *testing.T) {
+	for _, args := range [][]string{nil, {"-duration=-5s"}} {
+		err := cookCmd(context.Background(), func() {}, args)
+		if err == nil || !strings.Contains(err.Error(), "-duration") {
+			t.Errorf("cookCmd(%q) error = %v, want a -duration error", args, err)
+		}
+	}
+}
+
+// checkCmd Test Cases
+
+// TestCheckCmdReportsInvalidConfig verifies that check returns configuration errors.
+// Test logic: Runs check with an unknown log level, then with an OTLP timeout env var
+// that isn't a duration, and checks each error names the bad value.
+func TestCheckCmdReportsInvalidConfig(t *testing.T) {
+	err := checkCmd(context.Background(), func() {}, []string{"-log-level=loud"})
+	if err == nil || !strings.Contains(err.Error(), "loud") {
+		t.Errorf("checkCmd() error = %v, want it to report the log level", err)
+	}
+
+	// Env vars are checked too, not silently replaced by their defaults
+	t.Setenv("MEGAWAVE_OTLP_TIMEOUT", "abc")
+	err = checkCmd(context.Background(), func() {}, []string{"-env=test"})
+	if err == nil || !strings.Contains(err.Error(), "MEGAWAVE_OTLP_TIMEOUT") {
+		t.Errorf("checkCmd() error = %v, want it to report MEGAWAVE_OTLP_TIMEOUT", err)
+	}
+}
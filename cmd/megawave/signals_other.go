@@ -0,0 +1,8 @@
+//go:build !unix
+
+package main
+
+import "os"
+
+// statusSignals is empty where SIGUSR1 does not exist
+var statusSignals []os.Signal
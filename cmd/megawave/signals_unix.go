@@ -0,0 +1,11 @@
+//go:build unix
+
+package main
+
+import (
+	"os"
+	"syscall"
+)
+
+// statusSignals print every microwave's status as JSON (kill -USR1 <pid>)
+var statusSignals = []os.Signal{syscall.SIGUSR1}
@@ -0,0 +1,30 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/dskard/megawave/internal/microwave"
+)
+
+// instanceStatus is a microwave's status tagged with its instance number
+type instanceStatus struct {
+	Instance int `json:"instance"`
+	microwave.Status
+}
+
+// printStatus writes one JSON object per microwave to w. Lines end in \r\n
+// since the terminal is in raw mode while the program runs.
+func printStatus(w io.Writer, microwaves []*microwave.Microwave) error {
+	for i, m := range microwaves {
+		b, err := json.Marshal(instanceStatus{Instance: i + 1, Status: m.Status()})
+		if err != nil {
+			return err
+		}
+		if _, err := fmt.Fprintf(w, "%s\r\n", b); err != nil {
+			return err
+		}
+	}
+	return nil
+}
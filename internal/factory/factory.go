@@ -0,0 +1,36 @@
+// Package factory builds microwaves wired to the telemetry configured by
+// the telemetry package, so programs don't repeat the wiring.
+package factory
+
+import (
+	"context"
+	"log/slog"
+
+	"go.opentelemetry.io/otel"
+
+	"github.com/dskard/megawave/internal/microwave"
+	"github.com/dskard/megawave/internal/telemetry"
+)
+
+// MicrowaveOptions returns the options wiring a microwave to logger and to
+// the global tracer and meter providers, which telemetry.InitOTel sets in
+// production
+func MicrowaveOptions(logger *slog.Logger) []microwave.Option {
+	return []microwave.Option{
+		microwave.WithLogger(logger),
+		microwave.WithTracer(otel.Tracer("megawave")),
+		microwave.WithMeter(otel.Meter("megawave")),
+	}
+}
+
+// NewMicrowave sets up telemetry for cfg as telemetry.Setup does and
+// returns a microwave wired to it. Options in opts are applied after the
+// wiring, so they can add to or override it. The returned function is
+// telemetry.Setup's cleanup; call it once the microwave is done.
+func NewMicrowave(ctx context.Context, cfg telemetry.Config, opts ...microwave.Option) (*microwave.Microwave, func(), error) {
+	logger, cleanup, err := telemetry.Setup(ctx, cfg)
+	if err != nil {
+		return nil, nil, err
+	}
+	return microwave.New(append(MicrowaveOptions(logger), opts...)...), cleanup, nil
+}
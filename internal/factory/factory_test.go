@@ -0,0 +1,78 @@
+package factory
+
+import (
+	"context"
+	"log/slog"
+	"testing"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+
+	"github.com/dskard/megawave/internal/microwave"
+	"github.com/dskard/megawave/internal/telemetry"
+)
+
+// NewMicrowave Test Cases
+
+// TestNewMicrowaveWiring verifies that the microwave logs, traces and records metrics through the configured telemetry.
+// Test logic: Installs in-memory global tracer and meter providers, builds a microwave for a
+// Test environment config at warn level, and checks its logger honors the level. Then cooks
+// for one fast second and checks the cooking_session span and the button_presses metric
+// reached the global providers.
+func TestNewMicrowaveWiring(t *testing.T) {
+	// Capture what reaches the global providers, restoring them afterward
+	spans := tracetest.NewInMemoryExporter()
+	reader := sdkmetric.NewManualReader()
+	prevTP, prevMP := otel.GetTracerProvider(), otel.GetMeterProvider()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSyncer(spans)))
+	otel.SetMeterProvider(sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader)))
+	t.Cleanup(func() {
+		otel.SetTracerProvider(prevTP)
+		otel.SetMeterProvider(prevMP)
+	})
+
+	cfg := telemetry.Config{Environment: telemetry.Test, LogLevel: slog.LevelWarn}
+	m, cleanup, err := NewMicrowave(context.Background(), cfg, microwave.WithLogicalSecond(time.Millisecond))
+	if err != nil {
+		t.Fatalf("NewMicrowave() error = %v", err)
+	}
+	defer cleanup()
+
+	// The logger comes from the config
+	ctx := context.Background()
+	if m.Logger().Enabled(ctx, slog.LevelInfo) || !m.Logger().Enabled(ctx, slog.LevelWarn) {
+		t.Error("logger does not use the configured warn level")
+	}
+
+	// Cook for a second to produce a span and a metric
+	m.PressDigit(1)
+	m.PressStart(ctx)
+
+	// The tracer reports to the global tracer provider
+	var found bool
+	for _, s := range spans.GetSpans() {
+		found = found || s.Name == "cooking_session"
+	}
+	if !found {
+		t.Errorf("no cooking_session span recorded, got %d spans", len(spans.GetSpans()))
+	}
+
+	// The meter reports to the global meter provider
+	var rm metricdata.ResourceMetrics
+	if err := reader.Collect(ctx, &rm); err != nil {
+		t.Fatalf("Collect() error = %v", err)
+	}
+	found = false
+	for _, sm := range rm.ScopeMetrics {
+		for _, md := range sm.Metrics {
+			found = found || md.Name == "microwave.button_presses"
+		}
+	}
+	if !found {
+		t.Error("microwave.button_presses not recorded")
+	}
+}
@@ -0,0 +1,166 @@
+package microwave
+
+import (
+	"context"
+	"io"
+	"testing"
+	"time"
+
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace/noop"
+)
+
+// Benchmarks
+// Run with: go test -bench . -run '^$' ./internal/microwave
+
+// instantClock is a Clock whose timers fire immediately, so a countdown
+// benchmark measures the tick's own work rather than waiting
+type instantClock struct{}
+
+func (instantClock) Now() time.Time {
+	return time.Now()
+}
+
+func (instantClock) After(time.Duration) <-chan time.Time {
+	ch := make(chan time.Time, 1)
+	ch <- time.Now()
+	return ch
+}
+
+// BenchmarkPressDigit measures entering a digit, including the log call and
+// the display frame. The display is cleared after every fourth digit so
+// presses are never refused for a full display.
+func BenchmarkPressDigit(b *testing.B) {
+	m := New(WithOutput(io.Discard))
+	b.ReportAllocs()
+
+	i := 0
+	for b.Loop() {
+		m.PressDigit(i % 10)
+		i++
+		if i%4 == 0 {
+			m.mu.Lock()
+			m.digitCount = 0
+			m.mu.Unlock()
+		}
+	}
+}
+
+// BenchmarkDisplay measures a single reader polling the display snapshot.
+func BenchmarkDisplay(b *testing.B) {
+	m := New()
+	m.setDigits([4]int{0, 1, 3, 0})
+	b.ReportAllocs()
+
+	for b.Loop() {
+		_ = m.Display()
+	}
+}
+
+// BenchmarkCountdownTick measures a one second countdown: a tick that
+// updates the digits, publishes the display, logs and prints the frame,
+// followed by the final 00:00 frame.
+func BenchmarkCountdownTick(b *testing.B) {
+	m := New(WithOutput(io.Discard), WithClock(instantClock{}))
+	ctx := context.Background()
+	b.ReportAllocs()
+
+	for b.Loop() {
+		m.countdown(ctx, 1, nil)
+	}
+}
+
+// BenchmarkRemainingSecondsParallel measures lock-free polling of the remaining time.
+// Compare with BenchmarkRemainingSecondsLockedParallel, which reads the digits
+// under the mutex on every call.
+func BenchmarkRemainingSecondsParallel(b *testing.B) {
+	m := New()
+	m.remaining.Store(90)
+
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			_ = m.RemainingSeconds()
+		}
+	})
+}
+
+// BenchmarkRemainingSecondsLockedParallel measures computing the remaining time
+// from the digits under the mutex on every read, the approach the lock-free
+// counter replaced.
+func BenchmarkRemainingSecondsLockedParallel(b *testing.B) {
+	m := New()
+	m.mu.Lock()
+	m.setDigits([4]int{0, 1, 3, 0})
+	m.isCooking = true
+	m.mu.Unlock()
+
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			m.mu.Lock()
+			remaining := 0
+			if m.isCooking {
+				remaining = m.totalSeconds()
+			}
+			m.mu.Unlock()
+			_ = remaining
+		}
+	})
+}
+
+// BenchmarkDisplayParallel measures lock-free polling of the display snapshot.
+// Compare with BenchmarkDisplayLockedParallel, which formats the display under
+// the mutex on every read as Display used to.
+func BenchmarkDisplayParallel(b *testing.B) {
+	m := New()
+	m.setDigits([4]int{0, 1, 3, 0})
+	b.ReportAllocs()
+
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			_ = m.Display()
+		}
+	})
+}
+
+// BenchmarkDisplayLockedParallel measures formatting the display under the mutex
+// on every read, the approach the display snapshot replaced.
+func BenchmarkDisplayLockedParallel(b *testing.B) {
+	m := New()
+	m.setDigits([4]int{0, 1, 3, 0})
+	b.ReportAllocs()
+
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			m.mu.Lock()
+			_ = m.displayString()
+			m.mu.Unlock()
+		}
+	})
+}
+
+// BenchmarkStartSessionSpanNoop measures starting a cooking session span when
+// tracing is off. Attribute computation is skipped for non-recording spans.
+func BenchmarkStartSessionSpanNoop(b *testing.B) {
+	m := New(WithTracer(noop.NewTracerProvider().Tracer("bench")))
+	ctx := context.Background()
+	b.ReportAllocs()
+
+	for b.Loop() {
+		_, span := m.startSessionSpan(ctx, "session", 90, nil)
+		span.End()
+	}
+}
+
+// BenchmarkStartSessionSpanRecording measures starting a cooking session span
+// with a recording tracer, for comparison with BenchmarkStartSessionSpanNoop.
+func BenchmarkStartSessionSpanRecording(b *testing.B) {
+	tp := sdktrace.NewTracerProvider()
+	m := New(WithTracer(tp.Tracer("bench")))
+	ctx := context.Background()
+	b.ReportAllocs()
+
+	for b.Loop() {
+		_, span := m.startSessionSpan(ctx, "session", 90, nil)
+		span.End()
+	}
+}
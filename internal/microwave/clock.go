@@ -0,0 +1,24 @@
+package microwave
+
+import "time"
+
+// Clock abstracts the passage of time so tests can control the countdown.
+// Everything that moves a cook along, including ticks, warm-up, heartbeats,
+// display throttling and completion holds, reads the Clock and never the
+// time package, so a fake clock that isn't advanced freezes a cook between
+// ticks and Status can be inspected at a known point.
+type Clock interface {
+	Now() time.Time
+	After(d time.Duration) <-chan time.Time
+}
+
+// realClock is the default Clock backed by the time package
+type realClock struct{}
+
+func (realClock) Now() time.Time {
+	return time.Now()
+}
+
+func (realClock) After(d time.Duration) <-chan time.Time {
+	return time.After(d)
+}
@@ -0,0 +1,121 @@
+package microwave
+
+import (
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeClock is a Clock whose time only moves when Advance is called.
+// Timers created with After fire once the clock is advanced past their deadline.
+type fakeClock struct {
+	mu        sync.Mutex
+	now       time.Time
+	waiters   []fakeWaiter
+	durations []time.Duration // Every duration passed to After
+}
+
+type fakeWaiter struct {
+	when time.Time
+	ch   chan time.Time
+}
+
+func newFakeClock() *fakeClock {
+	return &fakeClock{now: time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)}
+}
+
+func (c *fakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+func (c *fakeClock) After(d time.Duration) <-chan time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.durations = append(c.durations, d)
+	ch := make(chan time.Time, 1)
+	if d <= 0 {
+		ch <- c.now
+		return ch
+	}
+	c.waiters = append(c.waiters, fakeWaiter{when: c.now.Add(d), ch: ch})
+	return ch
+}
+
+// Advance moves the clock forward and fires any timers that are due
+func (c *fakeClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.now = c.now.Add(d)
+	pending := c.waiters[:0]
+	for _, w := range c.waiters {
+		if !w.when.After(c.now) {
+			w.ch <- c.now
+		} else {
+			pending = append(pending, w)
+		}
+	}
+	c.waiters = pending
+}
+
+// BlockUntil waits until at least n timers are pending on the clock
+func (c *fakeClock) BlockUntil(t *testing.T, n int) {
+	t.Helper()
+
+	timeout := time.After(5 * time.Second)
+	for {
+		c.mu.Lock()
+		count := len(c.waiters)
+		c.mu.Unlock()
+		if count >= n {
+			return
+		}
+
+		select {
+		case <-timeout:
+			t.Fatalf("timed out waiting for %d pending timers, have %d", n, count)
+		default:
+			time.Sleep(time.Millisecond)
+		}
+	}
+}
+
+// Tick waits for the countdown to schedule its next tick and then advances
+// the clock one second, n times
+func (c *fakeClock) Tick(t *testing.T, n int) {
+	t.Helper()
+
+	for range n {
+		c.BlockUntil(t, 1)
+		c.Advance(1 * time.Second)
+	}
+}
+
+// fakeClock Test Cases
+
+// TestFakeClockAdvanceFiresTimers verifies that Advance fires only the timers that are due.
+// Test logic: Creates timers for 1s and 2s, advances 1s, and checks that only the first fired.
+func TestFakeClockAdvanceFiresTimers(t *testing.T) {
+	c := newFakeClock()
+
+	first := c.After(1 * time.Second)
+	second := c.After(2 * time.Second)
+
+	// Advance past the first deadline only
+	c.Advance(1 * time.Second)
+
+	select {
+	case <-first:
+	default:
+		t.Error("expected 1s timer to fire after advancing 1s")
+	}
+
+	select {
+	case <-second:
+		t.Error("2s timer should not fire after advancing 1s")
+	default:
+	}
+}
@@ -0,0 +1,109 @@
+package microwave
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+)
+
+// maxSeconds is the longest time the display can show (99:99)
+const maxSeconds = 99*60 + 99
+
+// defaultSeparator sits between the minutes and seconds digits
+const defaultSeparator = ":"
+
+// FormatDisplay converts seconds to the MM:SS string the microwave would show.
+// Times are clamped to the 00:00-99:99 range the display can show.
+func FormatDisplay(seconds int) string {
+	return formatDigits(secondsToDigits(seconds), defaultSeparator)
+}
+
+// RenderCountdown writes a countdown from seconds down to 00:00 to w, one
+// FormatDisplay frame per line, waiting interval between frames. It's a
+// simple timer for embedding without the appliance: there are no digits to
+// enter, logs or telemetry. Non-positive intervals wait one second. Returns
+// ctx.Err() if ctx is canceled first, or the first error writing to w.
+func RenderCountdown(ctx context.Context, w io.Writer, seconds int, interval time.Duration) error {
+	if interval <= 0 {
+		interval = time.Second
+	}
+	seconds = max(0, min(seconds, maxSeconds))
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		if _, err := fmt.Fprintln(w, FormatDisplay(seconds)); err != nil {
+			return err
+		}
+		if seconds == 0 {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+			seconds--
+		}
+	}
+}
+
+// ErrInvalidDisplay is returned when a string is not a valid MM:SS display
+var ErrInvalidDisplay = errors.New("invalid display")
+
+// ParseDisplay parses an MM:SS display string into total seconds.
+// It is the inverse of FormatDisplay. As on the keypad, the seconds
+// may be above 59, so "00:75" parses as 75 seconds.
+func ParseDisplay(s string) (int, error) {
+	return ParseDisplayWithSeparator(s, defaultSeparator)
+}
+
+// ParseDisplayWithSeparator parses a display that uses sep between the
+// minutes and seconds, as configured with WithSeparator. An empty sep
+// parses bare four-digit displays such as "0130".
+func ParseDisplayWithSeparator(s, sep string) (int, error) {
+	if len(s) != 4+len(sep) || s[2:2+len(sep)] != sep {
+		return 0, fmt.Errorf("%w %q: expected MM%sSS", ErrInvalidDisplay, s, sep)
+	}
+
+	secs := s[2+len(sep):]
+	var d [4]int
+	for i, c := range []byte{s[0], s[1], secs[0], secs[1]} {
+		if c < '0' || c > '9' {
+			return 0, fmt.Errorf("%w %q: %q is not a digit", ErrInvalidDisplay, s, c)
+		}
+		d[i] = int(c - '0')
+	}
+
+	return digitsToSeconds(d), nil
+}
+
+// formatDigits renders display digits as MM, sep, SS
+func formatDigits(d [4]int, sep string) string {
+	return fmt.Sprintf("%d%d%s%d%d", d[0], d[1], sep, d[2], d[3])
+}
+
+// secondsToDigits converts seconds to display digits [M1, M2, S1, S2].
+// Minutes beyond 99 are carried into the seconds digits, so 100 minutes
+// shows as 99:60, and anything above 99:99 is clamped.
+func secondsToDigits(seconds int) [4]int {
+	seconds = max(0, min(seconds, maxSeconds))
+
+	mins := seconds / 60
+	secs := seconds % 60
+	if mins > 99 {
+		secs += (mins - 99) * 60
+		mins = 99
+	}
+
+	return [4]int{mins / 10, mins % 10, secs / 10, secs % 10}
+}
+
+// digitsToSeconds converts display digits [M1, M2, S1, S2] to total seconds
+func digitsToSeconds(d [4]int) int {
+	minutes := d[0]*10 + d[1]
+	seconds := d[2]*10 + d[3]
+	return minutes*60 + seconds
+}
@@ -0,0 +1,162 @@
+package microwave
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+// FormatDisplay Test Cases
+
+// TestFormatDisplay verifies that FormatDisplay converts seconds to MM:SS with clamping.
+// Test logic: Uses table-driven tests to format in-range, carried-minute, and out-of-range
+// values and checks each against the expected display string.
+func TestFormatDisplay(t *testing.T) {
+	tests := []struct {
+		name     string
+		seconds  int
+		expected string
+	}{
+		{"zero", 0, "00:00"},
+		{"seconds only", 5, "00:05"},
+		{"minutes and seconds", 90, "01:30"},
+		{"100 minutes carried into seconds", 6000, "99:60"},
+		{"maximum", 6039, "99:99"},
+		{"above maximum is clamped", 7000, "99:99"},
+		{"negative is clamped", -5, "00:00"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := FormatDisplay(tt.seconds); got != tt.expected {
+				t.Errorf("FormatDisplay(%d) = %q, want %q", tt.seconds, got, tt.expected)
+			}
+		})
+	}
+}
+
+// RenderCountdown Test Cases
+
+// TestRenderCountdown verifies that RenderCountdown writes one frame per second down to 00:00.
+// Test logic: Renders a 3 second countdown with a 1ms interval into a buffer and checks the
+// frames are 00:03 through 00:00, one per line.
+func TestRenderCountdown(t *testing.T) {
+	var buf bytes.Buffer
+	if err := RenderCountdown(context.Background(), &buf, 3, time.Millisecond); err != nil {
+		t.Fatalf("RenderCountdown() error = %v", err)
+	}
+
+	want := "00:03\n00:02\n00:01\n00:00\n"
+	if got := buf.String(); got != want {
+		t.Errorf("frames = %q, want %q", got, want)
+	}
+}
+
+// TestRenderCountdownCanceled verifies that RenderCountdown stops when its context is canceled.
+// Test logic: Renders a 99:99 countdown with an hour interval on an already canceled context
+// and checks it returns context.Canceled after writing only the first frame.
+func TestRenderCountdownCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	var buf bytes.Buffer
+	if err := RenderCountdown(ctx, &buf, maxSeconds, time.Hour); !errors.Is(err, context.Canceled) {
+		t.Errorf("RenderCountdown() error = %v, want context.Canceled", err)
+	}
+	if got := buf.String(); got != "99:99\n" {
+		t.Errorf("frames = %q, want only the first frame", got)
+	}
+}
+
+// ParseDisplay Test Cases
+
+// TestParseDisplay verifies that ParseDisplay converts valid MM:SS strings to seconds.
+// Test logic: Uses table-driven tests to parse valid display strings and checks the
+// returned seconds and that no error is returned.
+func TestParseDisplay(t *testing.T) {
+	tests := []struct {
+		display  string
+		expected int
+	}{
+		{"00:00", 0},
+		{"00:05", 5},
+		{"01:30", 90},
+		{"00:75", 75},
+		{"99:99", 6039},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.display, func(t *testing.T) {
+			got, err := ParseDisplay(tt.display)
+			if err != nil {
+				t.Fatalf("ParseDisplay(%q) error = %v", tt.display, err)
+			}
+			if got != tt.expected {
+				t.Errorf("ParseDisplay(%q) = %d, want %d", tt.display, got, tt.expected)
+			}
+		})
+	}
+}
+
+// TestParseDisplayInvalid verifies that ParseDisplay rejects malformed strings.
+// Test logic: Uses table-driven tests to parse malformed display strings and checks
+// each returns an error wrapping ErrInvalidDisplay.
+func TestParseDisplayInvalid(t *testing.T) {
+	tests := []string{"1:2:3", "ab:cd", "12-34", "", "1:23", "12:345", " 1:23"}
+
+	for _, display := range tests {
+		t.Run(display, func(t *testing.T) {
+			_, err := ParseDisplay(display)
+			if !errors.Is(err, ErrInvalidDisplay) {
+				t.Errorf("ParseDisplay(%q) error = %v, want ErrInvalidDisplay", display, err)
+			}
+		})
+	}
+}
+
+// TestParseDisplayRoundTrip verifies that ParseDisplay reverses FormatDisplay.
+// Test logic: Formats every value from 0 to 99:99 and checks parsing returns the original seconds.
+func TestParseDisplayRoundTrip(t *testing.T) {
+	for seconds := 0; seconds <= maxSeconds; seconds++ {
+		display := FormatDisplay(seconds)
+		got, err := ParseDisplay(display)
+		if err != nil || got != seconds {
+			t.Fatalf("ParseDisplay(FormatDisplay(%d)) = %d, %v; want %d", seconds, got, err, seconds)
+		}
+	}
+}
+
+// TestParseDisplayWithSeparator verifies parsing displays with custom and empty separators.
+// Test logic: Parses valid displays using "", "." and ":" separators, then checks that a
+// display with the wrong separator or length is rejected with ErrInvalidDisplay.
+func TestParseDisplayWithSeparator(t *testing.T) {
+	valid := []struct {
+		display, sep string
+		expected     int
+	}{
+		{"0130", "", 90},
+		{"9999", "", 6039},
+		{"01.30", ".", 90},
+		{"01:30", ":", 90},
+	}
+	for _, tt := range valid {
+		got, err := ParseDisplayWithSeparator(tt.display, tt.sep)
+		if err != nil || got != tt.expected {
+			t.Errorf("ParseDisplayWithSeparator(%q, %q) = %d, %v; want %d", tt.display, tt.sep, got, err, tt.expected)
+		}
+	}
+
+	invalid := []struct{ display, sep string }{
+		{"01:30", ""},
+		{"0130", ":"},
+		{"013", ""},
+		{"01.30", ":"},
+	}
+	for _, tt := range invalid {
+		if _, err := ParseDisplayWithSeparator(tt.display, tt.sep); !errors.Is(err, ErrInvalidDisplay) {
+			t.Errorf("ParseDisplayWithSeparator(%q, %q) error = %v, want ErrInvalidDisplay", tt.display, tt.sep, err)
+		}
+	}
+}
@@ -0,0 +1,40 @@
+package microwave
+
+import (
+	"fmt"
+	"io"
+)
+
+// WriteMetrics writes the microwave's counters and current state to w in
+// the Prometheus text exposition format, for serving on a route of an
+// existing HTTP server. It works without the OTel Prometheus exporter and
+// reads the microwave's own bookkeeping, so it reports the same values
+// whatever meter is configured. Returns the first error writing to w.
+func (m *Microwave) WriteMetrics(w io.Writer) error {
+	m.mu.Lock()
+	sessions := m.sessions
+	cooked := m.cooked
+	cooking := 0
+	if m.isCooking {
+		cooking = 1
+	}
+	m.mu.Unlock()
+
+	metrics := []struct {
+		name, kind, help string
+		value            int64
+	}{
+		{"microwave_button_presses_total", "counter", "Button presses, accepted or not", int64(m.pressSeq.Load())},
+		{"microwave_cooking_sessions_total", "counter", "Cooking sessions started", int64(sessions)},
+		{"microwave_cooked_seconds_total", "counter", "Displayed seconds cooked across all sessions", int64(cooked)},
+		{"microwave_cooking", "gauge", "1 while a cook is running, 0 otherwise", int64(cooking)},
+		{"microwave_remaining_seconds", "gauge", "Seconds left in the current cook", m.remaining.Load()},
+	}
+	for _, mt := range metrics {
+		if _, err := fmt.Fprintf(w, "# HELP %s %s\n# TYPE %s %s\n%s %d\n",
+			mt.name, mt.help, mt.name, mt.kind, mt.name, mt.value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
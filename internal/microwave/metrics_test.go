@@ -0,0 +1,78 @@
+package microwave
+
+import (
+	"context"
+	"io"
+	"strings"
+	"testing"
+)
+
+// WriteMetrics Test Cases
+
+// TestWriteMetrics verifies that WriteMetrics renders the counters and state in Prometheus text format.
+// Test logic: Presses 2, an invalid digit, and start, cooks the 2 seconds out on a fake clock,
+// then checks the output has HELP and TYPE lines and the expected value for each metric.
+func TestWriteMetrics(t *testing.T) {
+	clock := newFakeClock()
+	m := New(WithClock(clock), WithOutput(io.Discard))
+
+	m.PressDigit(2)
+	m.PressDigit(-1)
+	done := make(chan struct{})
+	go func() {
+		m.PressStart(context.Background())
+		close(done)
+	}()
+	clock.Tick(t, 2)
+	<-done
+
+	var sb strings.Builder
+	if err := m.WriteMetrics(&sb); err != nil {
+		t.Fatalf("WriteMetrics() error = %v", err)
+	}
+	out := sb.String()
+
+	for _, want := range []string{
+		"# TYPE microwave_button_presses_total counter\nmicrowave_button_presses_total 3\n",
+		"# TYPE microwave_cooking_sessions_total counter\nmicrowave_cooking_sessions_total 1\n",
+		"# TYPE microwave_cooked_seconds_total counter\nmicrowave_cooked_seconds_total 2\n",
+		"# TYPE microwave_cooking gauge\nmicrowave_cooking 0\n",
+		"# TYPE microwave_remaining_seconds gauge\nmicrowave_remaining_seconds 0\n",
+		"# HELP microwave_button_presses_total Button presses, accepted or not\n",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("WriteMetrics() output missing %q:\n%s", want, out)
+		}
+	}
+}
+
+// TestWriteMetricsWhileCooking verifies that the gauges report a cook in progress.
+// Test logic: Starts a 5 second cook on a fake clock, ticks once, and checks WriteMetrics
+// reports cooking as 1 with 4 seconds remaining, then cancels the cook.
+func TestWriteMetricsWhileCooking(t *testing.T) {
+	clock := newFakeClock()
+	m := New(WithClock(clock), WithOutput(io.Discard))
+	m.PressDigit(5)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		m.PressStart(ctx)
+		close(done)
+	}()
+	clock.Tick(t, 1)
+	clock.BlockUntil(t, 1)
+
+	var sb strings.Builder
+	if err := m.WriteMetrics(&sb); err != nil {
+		t.Fatalf("WriteMetrics() error = %v", err)
+	}
+	for _, want := range []string{"\nmicrowave_cooking 1\n", "\nmicrowave_remaining_seconds 4\n"} {
+		if !strings.Contains(sb.String(), want) {
+			t.Errorf("WriteMetrics() output missing %q:\n%s", want, sb.String())
+		}
+	}
+
+	cancel()
+	<-done
+}
@@ -5,12 +5,19 @@ import (
 	"fmt"
 	"io"
 	"log/slog"
+	"math"
+	"math/rand/v2"
+	"os"
 	"sync"
+	"sync/atomic"
 	"time"
 
+	"github.com/google/uuid"
 	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/metric"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
 	"go.opentelemetry.io/otel/trace"
 )
 
@@ -19,13 +26,250 @@ type Microwave struct {
 	digits     [4]int // Stored as 4 digits: [M1, M2, S1, S2]
 	digitCount int    // Number of digits entered (max 4 affect display)
 	isCooking  bool
+	starting   bool               // A start has claimed the cook, but cookOnce hasn't begun it yet
+	cookStart  time.Time          // When the current cook started (zero when idle)
+	cancelCook context.CancelFunc // Cancels the current cook (nil when idle)
+	skipCook   *skipSignal        // Fired by SkipToEnd to finish the current cook (nil when idle)
+	cooked     int                // Seconds cooked across all sessions, for WithCookingBudget
+	sessions   int                // Cooks started, for WriteMetrics
+	probe      probeCook          // The StartToTemp cook in progress (zero otherwise)
+	lastCook   *CookResult        // Most recent finished cook (nil before the first)
+	firstTap   time.Time          // START press awaiting a second one, for WithDoubleTapStart
+	queued     int                // Seconds of the cook queued by QueueTime (0 if none)
+	session    string             // ID of the cook in progress ("" when idle)
+	lastStart  startRecord        // Most recent cook to start, for WithStartIdempotency
+	tokens     float64            // Digit presses left in the WithInputRateLimit bucket
+	tokensAt   time.Time          // When tokens was last refilled (zero before the first press)
+	holdUntil  time.Time          // End of the WithCookCompletionDelay hold (zero when none)
+	quietUntil time.Time          // End of the WithPostCookInputDelay window (zero when none)
+	history    []PressRecord      // Ring buffer of recent presses, for WithHistorySize
+	historyAt  int                // Where the next press goes once history is full
 	mu         sync.Mutex
 
-	logger          *slog.Logger
-	tracer          trace.Tracer
-	meter           metric.Meter
-	buttonPresses   metric.Int64Counter
-	cookingSessions metric.Int64Counter
+	// attrValues tracks the distinct values seen per per-cook metric
+	// attribute key, for WithMetricAttributeLimit (guarded by mu)
+	attrValues map[attribute.Key]map[string]bool
+
+	// remaining is read without the lock so frequent polling doesn't
+	// contend with the countdown
+	remaining atomic.Int64
+
+	// display is a snapshot of the formatted digits, republished whenever
+	// they change, so Display can be polled without the lock
+	display atomic.Pointer[string]
+
+	// pressSeq numbers button presses so logs show their exact order
+	// across goroutines
+	pressSeq atomic.Uint64
+
+	// finalFrame renders the frame printed when a countdown finishes, set
+	// by WithFinalFrame (nil prints the display)
+	finalFrame func(*Microwave) string
+
+	clock             Clock
+	out               io.Writer     // Where display frames are printed
+	displayThrottle   time.Duration // Minimum time between countdown frames (0 prints every tick)
+	heartbeat         time.Duration // Time between "cooking in progress" logs (0 disables them)
+	separator         string        // Between the minutes and seconds digits ("" for bare digits)
+	compactSubMinute  bool          // Drop the minutes digits while they are 00
+	speaker           Speaker       // Puts the display into words for DisplaySpoken
+	logicalSecond     time.Duration // Wall time that each displayed second takes
+	tickJitter        float64       // Fraction of the logical second to randomly add or remove per tick
+	jitterSeed        *uint64       // Seeds the jitter RNG (nil for a random seed)
+	rng               *rand.Rand    // Jitter source, guarded by mu
+	partialEntry      PartialEntryPolicy
+	normalizeOnStart  bool          // Rewrite entries like 00:75 as 01:15 when a cook starts
+	newID             func() string // Generates cooking session IDs
+	autoStartOnFull   bool
+	rateLimit         int // Digit presses allowed per ratePer (0 for unlimited)
+	ratePer           time.Duration
+	doubleTapWindow   time.Duration // Second START press must follow the first within this (0 disables)
+	startIdempotency  time.Duration // Identical starts within this of a cook starting are no-ops (0 disables)
+	cookQueue         bool          // Allow QueueTime during a cook
+	completionHold    time.Duration // How long 00:00 is held after a cook completes (0 disables)
+	postCookDelay     time.Duration // How long presses are ignored after a cook ends (0 disables)
+	warmup            time.Duration // Wait before each countdown begins (0 disables)
+	heatingRate       float64       // Degrees Celsius gained per displayed second by StartToTemp
+	historySize       int           // Presses kept for History (0 disables)
+	cookingBudget     time.Duration // Total cooking allowed across sessions (0 for unlimited)
+	attrLimit         int           // Max distinct values per per-cook metric attribute (0 for unlimited)
+	onComplete        func(CookResult)
+	onMinuteRollover  func(minutes int)
+	preStart          func(seconds int) error // Vetoes starts, set by WithPreStartValidator
+	onReject          func(RejectReason, string)
+	logger            *slog.Logger
+	logSanitizer      func(string) string // Applied to string log attributes, set by WithLogSanitizer
+	tickLogLevel      slog.Level          // Level of the per-tick "tick" logs
+	instrumentation   string              // Scope name of the default tracer and meter
+	tracer            trace.Tracer
+	spanRecorder      *tracetest.InMemoryExporter // Set by WithInMemoryTracing
+	meter             metric.Meter
+	buttonPresses     metric.Int64Counter
+	cookingSessions   metric.Int64Counter
+	clampedDurations  metric.Int64Counter
+	remainingAtCancel metric.Int64Histogram
+	budgetRejections  metric.Int64Counter
+	rateLimited       metric.Int64Counter
+	rejections        metric.Int64Counter
+	readMetrics       bool
+	displayReads      metric.Int64Counter // Only created with WithReadMetrics
+}
+
+// CookResult describes how a cooking session ended
+type CookResult struct {
+	Completed        bool      // True if the countdown finished, false if canceled
+	SessionID        string    // Unique ID of the cooking session
+	RequestedSeconds int       // Cooking time that was entered
+	ElapsedSeconds   int       // Seconds the cook actually ran
+	EndedAt          time.Time // When the cook completed or was canceled
+}
+
+// PressRecord is a button press kept by WithHistorySize
+type PressRecord struct {
+	Seq    uint64    // Sequence number, as logged with the press
+	Button string    // "digit", "backspace", "clear" or "start"
+	Digit  int       // Digit pressed, for digit presses (0 otherwise)
+	At     time.Time // When the button was pressed
+}
+
+// probeCook describes a StartToTemp cook, whose display shows the simulated
+// food temperature rather than the time left
+type probeCook struct {
+	target  int // Degrees Celsius at which the cook ends (0 when not probing)
+	seconds int // Length of the cook, to tell how far it has heated
+}
+
+// startRecord identifies a cook that started, so a duplicate start can be
+// recognized by its attributes, length and time
+type startRecord struct {
+	at        time.Time
+	attrs     attribute.Distinct
+	seconds   int
+	sessionID string
+}
+
+// Status is a point-in-time snapshot of a microwave, taken under a single
+// lock so its fields agree with each other
+type Status struct {
+	Display          string `json:"display"`
+	Cooking          bool   `json:"cooking"`
+	DigitCount       int    `json:"digit_count"`
+	ElapsedSeconds   int    `json:"elapsed_seconds"`
+	RemainingSeconds int    `json:"remaining_seconds"`
+	SessionID        string `json:"session_id,omitempty"` // Empty when idle
+}
+
+// OptionsSummary reports the settings a microwave was built with, for
+// diagnosing misconfiguration. Callbacks and injected dependencies are
+// reported only as whether they were set.
+type OptionsSummary struct {
+	TickInterval         time.Duration      // WithLogicalSecond
+	MaxDuration          time.Duration      // Longest cook the display can show
+	TickJitter           float64            // WithRandomizedTickJitter (0 when off)
+	JitterSeeded         bool               // WithJitterSeed
+	Separator            string             // WithSeparator
+	FinalFrame           bool               // WithFinalFrame
+	CompactSubMinute     bool               // WithCompactSubMinute
+	DisplayThrottle      time.Duration      // WithDisplayThrottle (0 prints every tick)
+	Heartbeat            time.Duration      // WithHeartbeat (0 when off)
+	PartialEntry         PartialEntryPolicy // WithPartialEntryPolicy
+	NormalizeOnStart     bool               // WithClockNormalizationOnStart
+	AutoStartOnFull      bool               // WithAutoStartOnFull
+	InputRateLimit       int                // WithInputRateLimit presses (0 for unlimited)
+	InputRatePer         time.Duration      // WithInputRateLimit period
+	DoubleTapWindow      time.Duration      // WithDoubleTapStart (0 when off)
+	StartIdempotency     time.Duration      // WithStartIdempotency (0 when off)
+	CookQueue            bool               // WithCookQueue
+	CompletionHold       time.Duration      // WithCookCompletionDelay (0 when off)
+	PostCookInputDelay   time.Duration      // WithPostCookInputDelay (0 when off)
+	Warmup               time.Duration      // WithWarmup (0 when off)
+	HeatingRate          float64            // WithHeatingRate
+	HistorySize          int                // WithHistorySize (0 when off)
+	CookingBudget        time.Duration      // WithCookingBudget (0 for unlimited)
+	MetricAttributeLimit int                // WithMetricAttributeLimit (0 for unlimited)
+	InMemoryTracing      bool               // WithInMemoryTracing
+	OnComplete           bool               // WithOnComplete
+	OnMinuteRollover     bool               // WithOnMinuteRollover
+	PreStartValidator    bool               // WithPreStartValidator
+	RejectionHandler     bool               // WithRejectionHandler
+	LogSanitizer         bool               // WithLogSanitizer
+	TickLogLevel         slog.Level         // WithTickLogLevel
+	InstrumentationName  string             // WithInstrumentationName
+	ReadMetrics          bool               // WithReadMetrics
+}
+
+// PartialEntryPolicy controls how PressStart treats fewer than four entered digits
+type PartialEntryPolicy int
+
+const (
+	// AsEntered cooks the digits as shown, so entering 1 cooks for 00:01
+	AsEntered PartialEntryPolicy = iota
+	// AssumeMinutes treats one or two entered digits as minutes, so entering 1 cooks for 01:00.
+	// This is the policy for users who expect 1 to mean a minute. It is not named
+	// AssumeSeconds: reading the entry as seconds is what AsEntered already does.
+	AssumeMinutes
+	// RejectPartial refuses to start unless all four digits were entered
+	RejectPartial
+)
+
+// RejectReason identifies why the microwave refused a button press or start
+type RejectReason int
+
+const (
+	// ZeroTime means start was pressed with 00:00 on the display
+	ZeroTime RejectReason = iota
+	// AlreadyCooking means start was pressed, or a duration set, while a cook was running
+	AlreadyCooking
+	// DigitWhileCooking means a digit or backspace was pressed while a cook was running
+	DigitWhileCooking
+	// MaxDigits means a fifth digit was pressed
+	MaxDigits
+	// InvalidDigit means PressDigit was called with a value outside 0-9
+	InvalidDigit
+	// PartialEntry means start was pressed with fewer than four digits under RejectPartial
+	PartialEntry
+	// DeadlinePassed means StartUntil was called with a deadline that is not in the future
+	DeadlinePassed
+	// NegativeDuration means SetDuration was called with a negative duration
+	NegativeDuration
+	// BudgetExhausted means a cook was refused because WithCookingBudget ran out
+	BudgetExhausted
+	// ValidatorRejected means the WithPreStartValidator hook vetoed a start
+	ValidatorRejected
+	// QueueFull means QueueTime was called while a cook was already queued
+	QueueFull
+	// RateLimited means a digit was pressed faster than WithInputRateLimit allows
+	RateLimited
+	// TargetTooLow means StartToTemp was called with a target the food is already at
+	TargetTooLow
+)
+
+// rejectReasonNames are the String forms of the reasons, indexed by value.
+// They appear as the reason attribute of the rejections metric, so they
+// must not change once released.
+var rejectReasonNames = [...]string{
+	ZeroTime:          "zero_time",
+	AlreadyCooking:    "already_cooking",
+	DigitWhileCooking: "digit_while_cooking",
+	MaxDigits:         "max_digits",
+	InvalidDigit:      "invalid_digit",
+	PartialEntry:      "partial_entry",
+	DeadlinePassed:    "deadline_passed",
+	NegativeDuration:  "negative_duration",
+	BudgetExhausted:   "budget_exhausted",
+	ValidatorRejected: "validator_rejected",
+	QueueFull:         "queue_full",
+	RateLimited:       "rate_limited",
+	TargetTooLow:      "target_too_low",
+}
+
+// String returns the stable snake_case name of the reason, such as
+// "already_cooking", for mapping to API responses and metric attributes
+func (r RejectReason) String() string {
+	if r < 0 || int(r) >= len(rejectReasonNames) {
+		return fmt.Sprintf("RejectReason(%d)", int(r))
+	}
+	return rejectReasonNames[r]
 }
 
 // Option is a functional option for configuring Microwave
@@ -34,17 +278,46 @@ type Option func(*Microwave)
 // New creates a new Microwave with the given options
 func New(opts ...Option) *Microwave {
 	m := &Microwave{
-		digits:     [4]int{0, 0, 0, 0},
-		digitCount: 0,
-		isCooking:  false,
-		logger:     slog.New(slog.NewTextHandler(io.Discard, nil)),
-		tracer:     otel.Tracer("megawave"),
-		meter:      otel.Meter("megawave"),
+		digits:          [4]int{0, 0, 0, 0},
+		digitCount:      0,
+		isCooking:       false,
+		clock:           realClock{},
+		out:             os.Stdout,
+		separator:       defaultSeparator,
+		speaker:         English{},
+		logicalSecond:   time.Second,
+		newID:           uuid.NewString,
+		logger:          slog.New(slog.NewTextHandler(io.Discard, nil)),
+		tickLogLevel:    slog.LevelDebug,
+		heatingRate:     defaultHeatingRate,
+		instrumentation: defaultInstrumentationName,
 	}
 
 	for _, opt := range opts {
 		opt(m)
 	}
+	// Create the tracer and meter once the instrumentation name is known
+	if m.spanRecorder != nil {
+		tp := sdktrace.NewTracerProvider(sdktrace.WithSyncer(m.spanRecorder))
+		m.tracer = tp.Tracer(m.instrumentation)
+	} else if m.tracer == nil {
+		m.tracer = otel.Tracer(m.instrumentation)
+	}
+	if m.meter == nil {
+		m.meter = otel.Meter(m.instrumentation)
+	}
+	if m.logSanitizer != nil {
+		m.logger = slog.New(&sanitizingHandler{next: m.logger.Handler(), sanitize: m.logSanitizer})
+	}
+	m.publishDisplay()
+
+	if m.tickJitter > 0 {
+		seed := rand.Uint64()
+		if m.jitterSeed != nil {
+			seed = *m.jitterSeed
+		}
+		m.rng = rand.New(rand.NewPCG(seed, seed))
+	}
 
 	// Initialize metrics
 	var err error
@@ -62,6 +335,51 @@ func New(opts ...Option) *Microwave {
 		m.logger.Warn("failed to create cooking_sessions counter", "error", err)
 	}
 
+	m.clampedDurations, err = m.meter.Int64Counter("microwave.clamped_durations",
+		metric.WithDescription("Cooking times clamped to the maximum display time"),
+	)
+	if err != nil {
+		m.logger.Warn("failed to create clamped_durations counter", "error", err)
+	}
+
+	m.remainingAtCancel, err = m.meter.Int64Histogram("microwave.remaining_at_cancel",
+		metric.WithDescription("Seconds left on the display when a cook was canceled"),
+		metric.WithUnit("s"),
+	)
+	if err != nil {
+		m.logger.Warn("failed to create remaining_at_cancel histogram", "error", err)
+	}
+
+	m.budgetRejections, err = m.meter.Int64Counter("microwave.budget_rejections",
+		metric.WithDescription("Cooks refused because the cooking budget was exhausted"),
+	)
+	if err != nil {
+		m.logger.Warn("failed to create budget_rejections counter", "error", err)
+	}
+
+	m.rateLimited, err = m.meter.Int64Counter("microwave.rate_limited_presses",
+		metric.WithDescription("Digit presses refused by the input rate limit"),
+	)
+	if err != nil {
+		m.logger.Warn("failed to create rate_limited_presses counter", "error", err)
+	}
+
+	m.rejections, err = m.meter.Int64Counter("microwave.rejections",
+		metric.WithDescription("Refused button presses and starts, by reason"),
+	)
+	if err != nil {
+		m.logger.Warn("failed to create rejections counter", "error", err)
+	}
+
+	if m.readMetrics {
+		m.displayReads, err = m.meter.Int64Counter("microwave.display_reads",
+			metric.WithDescription("Display and Status reads, by method"),
+		)
+		if err != nil {
+			m.logger.Warn("failed to create display_reads counter", "error", err)
+		}
+	}
+
 	return m
 }
 
@@ -72,6 +390,27 @@ func WithLogger(l *slog.Logger) Option {
 	}
 }
 
+// WithTickLogLevel sets the level of the "tick" log written every second
+// of a countdown, independently of other logs. The default is debug; a
+// level below the handler's, such as slog.LevelDebug-4, silences ticks
+// while keeping other debug logs.
+func WithTickLogLevel(level slog.Level) Option {
+	return func(m *Microwave) {
+		m.tickLogLevel = level
+	}
+}
+
+// WithLogSanitizer runs every string attribute value the microwave logs
+// through fn first, guarding against log injection from values such as
+// session IDs. NewLogSanitizer builds one that strips control characters
+// and truncates long values. Applies to the logger set with WithLogger
+// regardless of option order, and to the logger returned by Logger.
+func WithLogSanitizer(fn func(string) string) Option {
+	return func(m *Microwave) {
+		m.logSanitizer = fn
+	}
+}
+
 // WithTracer sets the OpenTelemetry tracer
 func WithTracer(t trace.Tracer) Option {
 	return func(m *Microwave) {
@@ -79,6 +418,31 @@ func WithTracer(t trace.Tracer) Option {
 	}
 }
 
+// WithInMemoryTracing records spans in memory instead of exporting them,
+// for local debugging without a tracing backend. Read them with RecordedSpans.
+// Replaces any tracer set with WithTracer.
+func WithInMemoryTracing() Option {
+	return func(m *Microwave) {
+		m.spanRecorder = tracetest.NewInMemoryExporter()
+	}
+}
+
+// defaultInstrumentationName is the scope name of the default tracer and meter
+const defaultInstrumentationName = "megawave"
+
+// WithInstrumentationName sets the instrumentation scope name of the
+// default tracer and meter, and of WithInMemoryTracing's tracer, so the
+// microwave's telemetry can be told apart from other components in the
+// backend. Defaults to "megawave". Has no effect on a tracer or meter
+// passed with WithTracer or WithMeter.
+func WithInstrumentationName(name string) Option {
+	return func(m *Microwave) {
+		if name != "" {
+			m.instrumentation = name
+		}
+	}
+}
+
 // WithMeter sets the OpenTelemetry meter
 func WithMeter(meter metric.Meter) Option {
 	return func(m *Microwave) {
@@ -86,16 +450,486 @@ func WithMeter(meter metric.Meter) Option {
 	}
 }
 
+// WithReadMetrics counts every Display and Status call in the
+// microwave.display_reads counter, tagged with the method, to measure how
+// hard clients poll. Off by default, since Display is otherwise lock-free
+// and allocation-free.
+func WithReadMetrics(enabled bool) Option {
+	return func(m *Microwave) {
+		m.readMetrics = enabled
+	}
+}
+
+// Precomputed attributes for the display_reads counter, so counting a
+// read doesn't allocate
+var (
+	displayReadAttrs = metric.WithAttributeSet(attribute.NewSet(attribute.String("method", "display")))
+	statusReadAttrs  = metric.WithAttributeSet(attribute.NewSet(attribute.String("method", "status")))
+)
+
+// WithClock sets the clock used for timing the countdown
+func WithClock(c Clock) Option {
+	return func(m *Microwave) {
+		m.clock = c
+	}
+}
+
+// WithOutput sets where display frames are printed. Defaults to os.Stdout.
+func WithOutput(w io.Writer) Option {
+	return func(m *Microwave) {
+		m.out = w
+	}
+}
+
+// WithSeparator sets what is shown between the minutes and seconds digits.
+// Use "" for displays that are four bare digits, such as "0130". Parse such
+// displays with ParseDisplayWithSeparator(s, m.Separator()).
+func WithSeparator(sep string) Option {
+	return func(m *Microwave) {
+		m.separator = sep
+	}
+}
+
+// WithCompactSubMinute drops the minutes digits from the display while they
+// are zero, as some appliances do, so 00:45 shows as ":45". Times of a
+// minute or more show all four digits. Off by default.
+func WithCompactSubMinute(enabled bool) Option {
+	return func(m *Microwave) {
+		m.compactSubMinute = enabled
+	}
+}
+
+// WithSpeaker sets how DisplaySpoken puts the display into words. Defaults
+// to English.
+func WithSpeaker(s Speaker) Option {
+	return func(m *Microwave) {
+		if s != nil {
+			m.speaker = s
+		}
+	}
+}
+
+// WithFinalFrame sets a function that renders the frame printed when a
+// countdown finishes, in place of 00:00, such as "End". The rendered frame
+// is also what the final tick logs. Display still reports 00:00. The
+// function is called without the microwave's lock held, so it may use the
+// microwave's accessors.
+func WithFinalFrame(fn func(m *Microwave) string) Option {
+	return func(m *Microwave) {
+		m.finalFrame = fn
+	}
+}
+
+// WithDisplayThrottle prints countdown frames at most once per d of clock
+// time, dropping the frames in between, so fast logical seconds don't flood
+// slow terminals. The first frame and the final 00:00 are always printed.
+// Non-positive durations print every tick.
+func WithDisplayThrottle(d time.Duration) Option {
+	return func(m *Microwave) {
+		m.displayThrottle = d
+	}
+}
+
+// WithHeartbeat logs "cooking in progress" at info level with the remaining
+// time every interval of clock time during a cook, so long cooks are visible
+// without debug-level ticks. The heartbeat is checked on each tick, so
+// intervals shorter than the logical second log once per tick.
+// Non-positive intervals disable the heartbeat.
+func WithHeartbeat(interval time.Duration) Option {
+	return func(m *Microwave) {
+		m.heartbeat = interval
+	}
+}
+
+// WithLogicalSecond sets how much wall time each displayed second takes.
+// The display still counts in seconds, so a 100ms logical second runs a
+// 00:03 cook in 300ms. Non-positive durations are ignored.
+func WithLogicalSecond(d time.Duration) Option {
+	return func(m *Microwave) {
+		if d > 0 {
+			m.logicalSecond = d
+		}
+	}
+}
+
+// WithRandomizedTickJitter randomly lengthens or shortens each tick by up to
+// fraction of the logical second, for testing consumers against irregular
+// timing. A fraction of 0.1 makes each tick last between 0.9 and 1.1 logical
+// seconds. Fractions outside (0, 1) are ignored, leaving ticks regular.
+func WithRandomizedTickJitter(fraction float64) Option {
+	return func(m *Microwave) {
+		if fraction > 0 && fraction < 1 {
+			m.tickJitter = fraction
+		}
+	}
+}
+
+// WithJitterSeed seeds the tick jitter RNG so jittered runs are repeatable
+func WithJitterSeed(seed uint64) Option {
+	return func(m *Microwave) {
+		m.jitterSeed = &seed
+	}
+}
+
+// WithPartialEntryPolicy sets how PressStart treats fewer than four entered digits
+func WithPartialEntryPolicy(p PartialEntryPolicy) Option {
+	return func(m *Microwave) {
+		m.partialEntry = p
+	}
+}
+
+// WithClockNormalizationOnStart rewrites an entry that isn't a valid clock
+// time, such as 00:75, as the equivalent time, 01:15, when a cook starts.
+// The cook time is unchanged; only the display is, so the "cooking started"
+// log and the session span show the normalized time. The countdown frames
+// are normalized either way.
+func WithClockNormalizationOnStart(enabled bool) Option {
+	return func(m *Microwave) {
+		m.normalizeOnStart = enabled
+	}
+}
+
+// WithIDGenerator sets the function that generates cooking session IDs.
+// Defaults to random UUIDs; tests can supply a deterministic generator.
+func WithIDGenerator(fn func() string) Option {
+	return func(m *Microwave) {
+		m.newID = fn
+	}
+}
+
+// WithAutoStartOnFull starts cooking automatically once the fourth digit
+// is entered. The fourth digit presses START for the user, so the start is
+// logged, counted and checked like any other: with WithDoubleTapStart it is
+// only the first tap. The cook runs in its own goroutine with a background
+// context; use CancelCook to stop it.
+func WithAutoStartOnFull(enabled bool) Option {
+	return func(m *Microwave) {
+		m.autoStartOnFull = enabled
+	}
+}
+
+// WithInputRateLimit refuses digit presses beyond presses per period, like
+// the debounce of a physical keypad, so a program flooding the keypad can't
+// swamp the microwave. Presses are metered with a token bucket on the
+// microwave's clock: bursts of up to presses go through, then presses are
+// allowed again as the bucket refills. Refused presses are reported as
+// RateLimited. Non-positive values disable the limit, which is the default.
+func WithInputRateLimit(presses int, per time.Duration) Option {
+	return func(m *Microwave) {
+		if presses > 0 && per > 0 {
+			m.rateLimit = presses
+			m.ratePer = per
+		}
+	}
+}
+
+// WithDoubleTapStart requires START to be pressed twice within window before
+// a cook begins, guarding touch interfaces against accidental taps. A single
+// press is ignored with a debug log. Only PressStart and StartWithAttributes
+// are affected. Non-positive windows start on the first press.
+func WithDoubleTapStart(window time.Duration) Option {
+	return func(m *Microwave) {
+		m.doubleTapWindow = window
+	}
+}
+
+// WithStartIdempotency treats a START press as a duplicate, and ignores it,
+// when a cook of the entered time started within window with the same
+// attributes. While that cook runs, the display shows its countdown rather
+// than an entry, so only the attributes are compared. This keeps a retried
+// start request, as sent by at-least-once delivery, from cooking twice even
+// after the first cook has finished. A different time entered within the
+// window cooks as usual. The duplicate is logged
+// with the session ID of the cook it repeats; Status reports that ID while
+// the cook runs. Non-positive windows disable the check, which is the
+// default.
+func WithStartIdempotency(window time.Duration) Option {
+	return func(m *Microwave) {
+		m.startIdempotency = window
+	}
+}
+
+// WithCookCompletionDelay holds the finished 00:00 on the display for d
+// after a cook completes, as an appliance does to show it is done. The
+// first button press during the hold only dismisses it, so a user can't
+// start entering the next time by accident; presses after the hold behave
+// as usual. Canceled cooks and cooks followed by a queued one aren't held.
+// Non-positive durations disable the hold, which is the default.
+func WithCookCompletionDelay(d time.Duration) Option {
+	return func(m *Microwave) {
+		m.completionHold = d
+	}
+}
+
+// WithPostCookInputDelay ignores digit, backspace and start presses for d
+// after a cook ends, whether it completed or was canceled, so a stray
+// keystroke meant for the finished cook doesn't carry into the next one.
+// Ignored presses are only logged at debug. Non-positive durations disable
+// the window, which is the default.
+func WithPostCookInputDelay(d time.Duration) Option {
+	return func(m *Microwave) {
+		m.postCookDelay = d
+	}
+}
+
+// WithWarmup waits d before each countdown begins, like a magnetron
+// warming up. The display holds the full time meanwhile, and the wait is
+// logged as "warming up". Canceling the cook or SkipToEnd ends the warm-up
+// too. The warm-up counts toward ElapsedSeconds but not RemainingSeconds,
+// WithCookingBudget or the cooked seconds in WriteMetrics.
+// Non-positive durations disable it, which is the default.
+func WithWarmup(d time.Duration) Option {
+	return func(m *Microwave) {
+		m.warmup = d
+	}
+}
+
+// WithHistorySize keeps the last n button presses, accepted or not, for
+// History, to help reproduce a reported input sequence. Non-positive sizes
+// keep no history, which is the default.
+func WithHistorySize(n int) Option {
+	return func(m *Microwave) {
+		m.historySize = n
+	}
+}
+
+// Simulated food temperatures for StartToTemp, in degrees Celsius
+const (
+	ambientC           = 20  // Where every StartToTemp cook starts heating from
+	defaultHeatingRate = 1.0 // Degrees gained per displayed second
+)
+
+// WithHeatingRate sets how many degrees Celsius the food gains per displayed
+// second in a StartToTemp cook. The default is 1. Non-positive rates are
+// ignored.
+func WithHeatingRate(degreesPerSecond float64) Option {
+	return func(m *Microwave) {
+		if degreesPerSecond > 0 {
+			m.heatingRate = degreesPerSecond
+		}
+	}
+}
+
+// WithCookQueue lets QueueTime queue one cook during a cook, to start
+// automatically when the current one completes
+func WithCookQueue(enabled bool) Option {
+	return func(m *Microwave) {
+		m.cookQueue = enabled
+	}
+}
+
+// WithCookingBudget caps the total time the microwave will cook across all
+// sessions, measured in displayed seconds, so WithWarmup's wait before each
+// countdown doesn't count. Once the seconds cooked reach the
+// budget, new cooks are refused; a cook that starts under budget is allowed
+// to finish even if it goes over. Non-positive budgets mean unlimited.
+func WithCookingBudget(total time.Duration) Option {
+	return func(m *Microwave) {
+		m.cookingBudget = total
+	}
+}
+
+// otherAttrValue replaces per-cook metric attribute values beyond the limit
+const otherAttrValue = "other"
+
+// WithMetricAttributeLimit caps how many distinct values each per-cook
+// attribute (from StartWithAttributes) may take on the cooking_sessions
+// metric. Once a key has n values, new values are recorded as "other" and a
+// warning is logged, protecting metric backends from cardinality blowups.
+// Spans keep the original values. Non-positive limits mean unlimited.
+func WithMetricAttributeLimit(n int) Option {
+	return func(m *Microwave) {
+		m.attrLimit = n
+	}
+}
+
+// WithOnComplete sets a callback that is invoked once when each cook ends,
+// whether it completed or was canceled
+func WithOnComplete(fn func(CookResult)) Option {
+	return func(m *Microwave) {
+		m.onComplete = fn
+	}
+}
+
+// WithOnMinuteRollover sets a callback that is invoked from the countdown
+// each time the displayed minutes drop, such as from 02:00 to 01:59, so a
+// UI can emphasize the change. It receives the minutes now shown. Every
+// rollover is also logged and added as a minute_rollover event to the
+// cooking_session span, with or without a callback.
+func WithOnMinuteRollover(fn func(minutes int)) Option {
+	return func(m *Microwave) {
+		m.onMinuteRollover = fn
+	}
+}
+
+// WithPreStartValidator sets a hook that can veto a start. It is called by
+// PressStart with the cooking time in seconds after the built-in zero time
+// and partial entry checks; a non-nil error aborts the start, is logged,
+// and is reported to the rejection handler as ValidatorRejected.
+func WithPreStartValidator(fn func(seconds int) error) Option {
+	return func(m *Microwave) {
+		m.preStart = fn
+	}
+}
+
+// WithRejectionHandler sets a callback that is invoked whenever a press or
+// start is refused, so a UI can flash an error light. The detail is the same
+// human readable message that is logged. The callback runs without the
+// microwave's lock held.
+func WithRejectionHandler(fn func(reason RejectReason, detail string)) Option {
+	return func(m *Microwave) {
+		m.onReject = fn
+	}
+}
+
+// TickInterval returns how much wall time each displayed second takes, as
+// set by WithLogicalSecond. With tick jitter on, individual ticks vary
+// around this value.
+func (m *Microwave) TickInterval() time.Duration {
+	return m.logicalSecond
+}
+
+// MaxDuration returns the longest cooking time the display can show (99:99),
+// measured in displayed seconds. Multiply by TickInterval()/time.Second for
+// the wall time such a cook takes.
+func (m *Microwave) MaxDuration() time.Duration {
+	return maxSeconds * time.Second
+}
+
+// Options summarizes the settings the microwave was built with. Settings
+// never change after New, so no lock is needed.
+func (m *Microwave) Options() OptionsSummary {
+	return OptionsSummary{
+		TickInterval:         m.logicalSecond,
+		MaxDuration:          m.MaxDuration(),
+		TickJitter:           m.tickJitter,
+		JitterSeeded:         m.jitterSeed != nil,
+		Separator:            m.separator,
+		FinalFrame:           m.finalFrame != nil,
+		CompactSubMinute:     m.compactSubMinute,
+		DisplayThrottle:      max(m.displayThrottle, 0),
+		Heartbeat:            max(m.heartbeat, 0),
+		PartialEntry:         m.partialEntry,
+		NormalizeOnStart:     m.normalizeOnStart,
+		AutoStartOnFull:      m.autoStartOnFull,
+		InputRateLimit:       m.rateLimit,
+		InputRatePer:         m.ratePer,
+		DoubleTapWindow:      max(m.doubleTapWindow, 0),
+		StartIdempotency:     max(m.startIdempotency, 0),
+		CookQueue:            m.cookQueue,
+		CompletionHold:       max(m.completionHold, 0),
+		PostCookInputDelay:   max(m.postCookDelay, 0),
+		Warmup:               max(m.warmup, 0),
+		HeatingRate:          m.heatingRate,
+		HistorySize:          max(m.historySize, 0),
+		CookingBudget:        max(m.cookingBudget, 0),
+		MetricAttributeLimit: max(m.attrLimit, 0),
+		InMemoryTracing:      m.spanRecorder != nil,
+		OnComplete:           m.onComplete != nil,
+		OnMinuteRollover:     m.onMinuteRollover != nil,
+		PreStartValidator:    m.preStart != nil,
+		RejectionHandler:     m.onReject != nil,
+		LogSanitizer:         m.logSanitizer != nil,
+		TickLogLevel:         m.tickLogLevel,
+		InstrumentationName:  m.instrumentation,
+		ReadMetrics:          m.readMetrics,
+	}
+}
+
+// Logger returns the microwave's logger so callers can emit logs through
+// the same handler
+func (m *Microwave) Logger() *slog.Logger {
+	return m.logger
+}
+
+// RecordedSpans returns the spans recorded so far with WithInMemoryTracing,
+// or nil if in-memory tracing is off. Spans appear once they end.
+func (m *Microwave) RecordedSpans() []tracetest.SpanStub {
+	if m.spanRecorder == nil {
+		return nil
+	}
+	return m.spanRecorder.GetSpans()
+}
+
 // displayString returns the display without locking (caller must hold lock)
 func (m *Microwave) displayString() string {
-	return fmt.Sprintf("%d%d:%d%d", m.digits[0], m.digits[1], m.digits[2], m.digits[3])
+	if m.probe.target > 0 {
+		return m.probeDisplay()
+	}
+	if m.compactSubMinute && m.digits[0] == 0 && m.digits[1] == 0 {
+		return fmt.Sprintf("%s%d%d", m.separator, m.digits[2], m.digits[3])
+	}
+	return formatDigits(m.digits, m.separator)
 }
 
-// Display returns the current display value as MM:SS
+// probeDisplay shows the simulated temperature of a StartToTemp cook, such
+// as "45C", worked out from how much of the cook has run. Caller must hold m.mu.
+func (m *Microwave) probeDisplay() string {
+	elapsed := min(m.probe.seconds, maxSeconds) - digitsToSeconds(m.digits)
+	temp := min(ambientC+int(m.heatingRate*float64(elapsed)), m.probe.target)
+	return fmt.Sprintf("%dC", temp)
+}
+
+// Display returns the current display value as MM:SS, or :SS under one
+// minute with WithCompactSubMinute. It reads a snapshot
+// that is republished whenever the digits change, so polling never takes
+// the lock.
 func (m *Microwave) Display() string {
+	if m.displayReads != nil {
+		m.displayReads.Add(context.Background(), 1, displayReadAttrs)
+	}
+	return *m.display.Load()
+}
+
+// setDigits replaces the display digits and republishes the display
+// snapshot (caller must hold lock)
+func (m *Microwave) setDigits(digits [4]int) {
+	m.digits = digits
+	m.publishDisplay()
+}
+
+// publishDisplay stores the current digits as the snapshot read by Display
+// (caller must hold lock)
+func (m *Microwave) publishDisplay() {
+	s := m.displayString()
+	m.display.Store(&s)
+}
+
+// DisplaySegments returns the four display digits as [M1, M2, S1, S2]
+// so a renderer can draw each digit itself
+func (m *Microwave) DisplaySegments() [4]int {
 	m.mu.Lock()
 	defer m.mu.Unlock()
-	return m.displayString()
+	return m.digits
+}
+
+// IsValidClockTime reports whether the display shows a real clock time,
+// with the seconds below 60. Entries like 00:75 are accepted as 75 seconds
+// but aren't valid clock times, so WithClockNormalizationOnStart would
+// show them as 01:15.
+func (m *Microwave) IsValidClockTime() bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.digits[2] < 6
+}
+
+// DisplaySpoken returns the current display as words, such as "one minute
+// thirty-five seconds" for 01:35, for accessibility announcements
+func (m *Microwave) DisplaySpoken() string {
+	d := m.DisplaySegments()
+	return m.speaker.Speak(d[0]*10+d[1], d[2]*10+d[3])
+}
+
+// ColonLit returns whether the colon between minutes and seconds is lit.
+// The display does not blink, so the colon is lit unless the display has
+// no separator.
+func (m *Microwave) ColonLit() bool {
+	return m.separator != ""
+}
+
+// Separator returns what is shown between the minutes and seconds digits
+func (m *Microwave) Separator() string {
+	return m.separator
 }
 
 // IsCooking returns whether the microwave is currently cooking
@@ -105,54 +939,378 @@ func (m *Microwave) IsCooking() bool {
 	return m.isCooking
 }
 
+// ElapsedSeconds returns how many seconds the current cook has been running.
+// Returns 0 when the microwave is not cooking.
+func (m *Microwave) ElapsedSeconds() int {
+	now := m.clock.Now()
+
+	m.mu.Lock()
+	cooking := m.isCooking
+	start := m.cookStart
+	m.mu.Unlock()
+
+	if !cooking {
+		return 0
+	}
+	return int(now.Sub(start) / m.logicalSecond)
+}
+
+// Status returns a consistent snapshot of the display, cooking state and
+// timing, for monitoring a running microwave
+func (m *Microwave) Status() Status {
+	if m.displayReads != nil {
+		m.displayReads.Add(context.Background(), 1, statusReadAttrs)
+	}
+	now := m.clock.Now()
+
+	m.mu.Lock()
+	s := Status{
+		Display:          m.displayString(),
+		Cooking:          m.isCooking,
+		DigitCount:       m.digitCount,
+		RemainingSeconds: int(m.remaining.Load()),
+		SessionID:        m.session,
+	}
+	start := m.cookStart
+	m.mu.Unlock()
+
+	if s.Cooking {
+		s.ElapsedSeconds = int(now.Sub(start) / m.logicalSecond)
+	}
+	return s
+}
+
+// LastCook returns the summary of the most recent cook to complete or be
+// canceled, or false if no cook has finished yet
+func (m *Microwave) LastCook() (CookResult, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.lastCook == nil {
+		return CookResult{}, false
+	}
+	return *m.lastCook, true
+}
+
+// History returns the recent button presses kept by WithHistorySize,
+// oldest first. Returns nil when history is off.
+func (m *Microwave) History() []PressRecord {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if len(m.history) == 0 {
+		return nil
+	}
+	history := make([]PressRecord, 0, len(m.history))
+	history = append(history, m.history[m.historyAt:]...)
+	return append(history, m.history[:m.historyAt]...)
+}
+
+// recordPress adds a press to the WithHistorySize history, overwriting the
+// oldest one once it is full
+func (m *Microwave) recordPress(seq uint64, button string, digit int) {
+	if m.historySize <= 0 {
+		return
+	}
+
+	r := PressRecord{Seq: seq, Button: button, Digit: digit, At: m.clock.Now()}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if len(m.history) < m.historySize {
+		m.history = append(m.history, r)
+		return
+	}
+	m.history[m.historyAt] = r
+	m.historyAt = (m.historyAt + 1) % m.historySize
+}
+
+// RemainingSeconds returns the seconds left in the current cook.
+// Returns 0 when the microwave is not cooking. It does not take the lock,
+// so it is cheap to poll.
+func (m *Microwave) RemainingSeconds() int {
+	return int(m.remaining.Load())
+}
+
+// EstimatedCompletion returns when the current cook should finish by the
+// microwave's clock: now plus the remaining seconds, each lasting one
+// logical second. The estimate can run up to one tick late, since the
+// second being displayed may be partly over, and drifts with tick jitter.
+// Returns false when the microwave is not cooking.
+func (m *Microwave) EstimatedCompletion() (time.Time, bool) {
+	now := m.clock.Now()
+
+	m.mu.Lock()
+	cooking := m.isCooking
+	remaining := m.remaining.Load()
+	m.mu.Unlock()
+
+	if !cooking {
+		return time.Time{}, false
+	}
+	return now.Add(time.Duration(remaining) * m.logicalSecond), true
+}
+
 // PressDigit handles a digit button press (0-9)
 // PressDigit does not accept negative integers or integers above 9.
 // PressDigit ignores digit button presses while the microwave is cooking.
 func (m *Microwave) PressDigit(d int) {
+	seq := m.pressSeq.Add(1)
+	m.recordPress(seq, "digit", d)
 	if d < 0 || d > 9 {
-		m.logger.Warn("invalid digit ignored", "digit", d)
+		m.logger.Warn("invalid digit ignored", "digit", d, "seq", seq)
+		m.reject(InvalidDigit, "invalid digit ignored")
+		return
+	}
+
+	if !m.allowPress() {
+		m.logger.Warn("input rate limited", "digit", d, "seq", seq, "limit", m.rateLimit, "per", m.ratePer)
+		if m.rateLimited != nil {
+			m.rateLimited.Add(context.Background(), 1)
+		}
+		m.reject(RateLimited, "input rate limited")
+		return
+	}
+
+	cooking := m.IsCooking()
+
+	// Always log and record metrics, even while cooking
+	m.logger.Info("digit pressed", "digit", d, "cooking", cooking, "seq", seq)
+	if m.buttonPresses != nil {
+		m.buttonPresses.Add(context.Background(), 1,
+			metric.WithAttributes(
+				attribute.String("type", "digit"),
+				attribute.Bool("while_cooking", cooking),
+			),
+		)
+	}
+
+	// Don't allow pressing digits while cooking
+	if cooking {
+		m.logger.Warn("digit ignored while cooking", "digit", d, "seq", seq)
+		m.reject(DigitWhileCooking, "digit ignored while cooking")
+		return
+	}
+	if m.postCookQuiet(seq) {
+		return
+	}
+	if m.dismissHold(seq) {
+		return
+	}
+
+	m.mu.Lock()
+	if m.digitCount >= 4 {
+		m.mu.Unlock()
+		m.logger.Warn("max digits reached, display not updated", "digit", d, "seq", seq)
+		m.reject(MaxDigits, "max digits reached, display not updated")
+		return
+	}
+
+	// Shift digits left and add new digit
+	m.digits[0] = m.digits[1]
+	m.digits[1] = m.digits[2]
+	m.digits[2] = m.digits[3]
+	m.digits[3] = d
+	m.digitCount++
+	m.publishDisplay()
+
+	display := m.displayString()
+	digitCount := m.digitCount
+	m.mu.Unlock()
+
+	m.logger.Debug("display updated", "display", display, "digitCount", digitCount)
+	fmt.Fprint(m.out, display+"\r\n")
+
+	if m.autoStartOnFull && digitCount == 4 {
+		m.logger.Info("display full, starting automatically")
+		go m.StartWithAttributes(context.Background())
+	}
+}
+
+// allowPress applies WithInputRateLimit, taking a press from the token
+// bucket. Returns false if the bucket is empty.
+func (m *Microwave) allowPress() bool {
+	if m.rateLimit <= 0 {
+		return true
+	}
+
+	now := m.clock.Now()
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.tokensAt.IsZero() {
+		m.tokens = float64(m.rateLimit)
+	} else {
+		refill := float64(now.Sub(m.tokensAt)) / float64(m.ratePer) * float64(m.rateLimit)
+		m.tokens = min(m.tokens+refill, float64(m.rateLimit))
+	}
+	m.tokensAt = now
+
+	if m.tokens < 1 {
+		return false
+	}
+	m.tokens--
+	return true
+}
+
+// postCookQuiet applies WithPostCookInputDelay. Returns true if the press
+// with sequence number seq came too soon after a cook ended and should be
+// ignored.
+func (m *Microwave) postCookQuiet(seq uint64) bool {
+	if m.postCookDelay <= 0 {
+		return false
+	}
+
+	now := m.clock.Now()
+	m.mu.Lock()
+	quiet := now.Before(m.quietUntil)
+	m.mu.Unlock()
+
+	if quiet {
+		m.logger.Debug("press ignored just after cook", "seq", seq, "delay", m.postCookDelay)
+	}
+	return quiet
+}
+
+// dismissHold ends a WithCookCompletionDelay hold. Returns true if the hold
+// was still in effect, in which case the press with sequence number seq
+// only dismissed it and should go no further.
+func (m *Microwave) dismissHold(seq uint64) bool {
+	if m.completionHold <= 0 {
+		return false
+	}
+
+	now := m.clock.Now()
+	m.mu.Lock()
+	holding := now.Before(m.holdUntil)
+	m.holdUntil = time.Time{}
+	m.mu.Unlock()
+
+	if holding {
+		m.logger.Info("completion hold dismissed", "seq", seq)
+	}
+	return holding
+}
+
+// PressBackspace handles a BACKSPACE button press, erasing the most recently
+// entered digit so the others shift back right. Pressing it with nothing
+// entered does nothing. Ignored while the microwave is cooking.
+func (m *Microwave) PressBackspace() {
+	seq := m.pressSeq.Add(1)
+	m.recordPress(seq, "backspace", 0)
+	cooking := m.IsCooking()
+
+	// Always log and record metrics, even while cooking
+	m.logger.Info("backspace pressed", "cooking", cooking, "seq", seq)
+	if m.buttonPresses != nil {
+		m.buttonPresses.Add(context.Background(), 1,
+			metric.WithAttributes(
+				attribute.String("type", "backspace"),
+				attribute.Bool("while_cooking", cooking),
+			),
+		)
+	}
+
+	if cooking {
+		m.logger.Warn("backspace ignored while cooking", "seq", seq)
+		m.reject(DigitWhileCooking, "backspace ignored while cooking")
+		return
+	}
+	if m.postCookQuiet(seq) {
+		return
+	}
+	if m.dismissHold(seq) {
+		return
+	}
+
+	m.mu.Lock()
+	if m.digitCount == 0 {
+		m.mu.Unlock()
 		return
 	}
 
+	// Shift digits right, dropping the last one entered
+	m.setDigits([4]int{0, m.digits[0], m.digits[1], m.digits[2]})
+	m.digitCount--
+
+	display := m.displayString()
+	digitCount := m.digitCount
+	m.mu.Unlock()
+
+	m.logger.Debug("display updated", "display", display, "digitCount", digitCount)
+	fmt.Fprint(m.out, display+"\r\n")
+}
+
+// PressClear handles a CLEAR button press, resetting everything in one
+// action: it cancels the cook in progress, as CancelCook does, and erases
+// the entered digits, showing 00:00. It also dismisses a
+// WithCookCompletionDelay hold. Unlike other presses it works while cooking.
+func (m *Microwave) PressClear(ctx context.Context) {
+	seq := m.pressSeq.Add(1)
+	m.recordPress(seq, "clear", 0)
 	cooking := m.IsCooking()
 
-	// Always log and record metrics, even while cooking
-	m.logger.Info("digit pressed", "digit", d, "cooking", cooking)
 	if m.buttonPresses != nil {
-		m.buttonPresses.Add(context.Background(), 1,
+		m.buttonPresses.Add(ctx, 1,
 			metric.WithAttributes(
-				attribute.String("type", "digit"),
+				attribute.String("type", "clear"),
 				attribute.Bool("while_cooking", cooking),
 			),
 		)
 	}
 
-	// Don't allow pressing digits while cooking
-	if cooking {
-		m.logger.Warn("digit ignored while cooking", "digit", d)
+	canceled := m.CancelCook()
+
+	m.mu.Lock()
+	m.setDigits([4]int{0, 0, 0, 0})
+	m.digitCount = 0
+	m.holdUntil = time.Time{}
+	display := m.displayString()
+	m.mu.Unlock()
+
+	m.logger.InfoContext(ctx, "cleared", "canceled_cook", canceled, "seq", seq)
+	fmt.Fprint(m.out, display+"\r\n")
+}
+
+// SetDuration enters a cooking time directly instead of pressing digits,
+// replacing anything already entered. The time is shown normalized, so 90s
+// displays as 01:30, and counts as a full four-digit entry. Fractions of a
+// second round up. Negative durations are rejected and leave the display
+// unchanged; durations beyond MaxDuration are clamped to 99:99. Ignored while
+// cooking. Unlike PressDigit, it never triggers WithAutoStartOnFull.
+func (m *Microwave) SetDuration(d time.Duration) {
+	if d < 0 {
+		m.logger.Warn("negative duration rejected", "duration", d)
+		m.reject(NegativeDuration, "negative duration rejected")
 		return
 	}
 
+	requested := d
+	clamped := d > m.MaxDuration()
+	if clamped {
+		d = m.MaxDuration()
+	}
+	seconds := int((d + time.Second - 1) / time.Second)
+	digitCount := 4
+	if seconds == 0 {
+		digitCount = 0
+	}
+
 	m.mu.Lock()
-	if m.digitCount >= 4 {
+	if m.isCooking {
 		m.mu.Unlock()
-		m.logger.Warn("max digits reached, display not updated", "digit", d)
+		m.logger.Warn("duration ignored while cooking", "duration", requested)
+		m.reject(AlreadyCooking, "duration ignored while cooking")
 		return
 	}
-
-	// Shift digits left and add new digit
-	m.digits[0] = m.digits[1]
-	m.digits[1] = m.digits[2]
-	m.digits[2] = m.digits[3]
-	m.digits[3] = d
-	m.digitCount++
-
+	m.setDigits(secondsToDigits(seconds))
+	m.digitCount = digitCount
 	display := m.displayString()
-	digitCount := m.digitCount
 	m.mu.Unlock()
 
-	m.logger.Debug("display updated", "display", display, "digitCount", digitCount)
-	fmt.Print(display + "\r\n")
+	if clamped {
+		m.logger.Warn("duration clamped to maximum", "duration", requested, "max", m.MaxDuration())
+	}
+	m.logger.Info("duration set", "display", display, "seconds", seconds)
+	fmt.Fprint(m.out, display+"\r\n")
 }
 
 // PressStart handles the START button press.
@@ -161,11 +1319,24 @@ func (m *Microwave) PressDigit(d int) {
 // respect context cancellation (e.g., Ctrl-C) to allow graceful application shutdown.
 // If the intent was to ignore all interrupts during cooking, use context.Background()
 // instead of the passed context.
+//
+// Every cook runs on its own cancelable context derived from ctx, so CancelCook
+// can stop it even when ctx can never be canceled, such as context.Background()
+// or context.TODO().
 func (m *Microwave) PressStart(ctx context.Context) {
+	m.StartWithAttributes(ctx)
+}
+
+// StartWithAttributes presses START like PressStart, and tags this cook's
+// cooking_sessions metric and cooking_session span with attrs, e.g. the ID of
+// the user or request that started it.
+func (m *Microwave) StartWithAttributes(ctx context.Context, attrs ...attribute.KeyValue) {
+	seq := m.pressSeq.Add(1)
+	m.recordPress(seq, "start", 0)
 	cooking := m.IsCooking()
 
 	// Always log and record metrics, even while cooking
-	m.logger.Info("start pressed", "cooking", cooking)
+	m.logger.Info("start pressed", "cooking", cooking, "seq", seq)
 	if m.buttonPresses != nil {
 		m.buttonPresses.Add(ctx, 1,
 			metric.WithAttributes(
@@ -175,125 +1346,674 @@ func (m *Microwave) PressStart(ctx context.Context) {
 		)
 	}
 
+	if sessionID, ok := m.duplicateStart(attrs); ok {
+		m.logger.InfoContext(ctx, "duplicate start ignored", "session_id", sessionID, "window", m.startIdempotency, "seq", seq)
+		return
+	}
+
 	if cooking {
-		m.logger.WarnContext(ctx, "start ignored, already cooking")
+		m.logger.WarnContext(ctx, "start ignored, already cooking", "seq", seq)
+		m.reject(AlreadyCooking, "start ignored, already cooking")
+		return
+	}
+	if m.postCookQuiet(seq) {
+		return
+	}
+	if m.dismissHold(seq) {
+		return
+	}
+
+	if !m.secondTap() {
+		m.logger.DebugContext(ctx, "start ignored, press again to confirm", "window", m.doubleTapWindow, "seq", seq)
+		return
+	}
+
+	// Another start may have won the race since cooking was read above
+	if !m.claimCook() {
+		m.logger.WarnContext(ctx, "start ignored, already cooking", "seq", seq)
+		m.reject(AlreadyCooking, "start ignored, already cooking")
 		return
 	}
+	m.start(ctx, attrs)
+}
+
+// claimCook reserves the next cook for the caller, checking and setting
+// starting in one step so two concurrent starts can't both begin a cook.
+// Returns false if a cook is running or already claimed. isCooking stays
+// false until cookOnce begins the cook and it can be canceled. Whoever
+// claims the cook must either run it with cookOnce or call releaseCook.
+func (m *Microwave) claimCook() bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.isCooking || m.starting {
+		return false
+	}
+	m.starting = true
+	return true
+}
+
+// releaseCook gives up a claim made with claimCook when the cook is
+// rejected before it begins
+func (m *Microwave) releaseCook() {
+	m.mu.Lock()
+	m.starting = false
+	m.mu.Unlock()
+}
+
+// duplicateStart applies WithStartIdempotency. Returns the session ID of the
+// cook that a start with attrs repeats, and true if there is one.
+func (m *Microwave) duplicateStart(attrs []attribute.KeyValue) (string, bool) {
+	if m.startIdempotency <= 0 {
+		return "", false
+	}
+
+	set := attribute.NewSet(attrs...)
+	key := set.Equivalent()
+	now := m.clock.Now()
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	last := m.lastStart
+	if last.at.IsZero() || now.Sub(last.at) > m.startIdempotency || last.attrs != key {
+		return "", false
+	}
+	if !m.isCooking && digitsToSeconds(m.enteredDigits()) != last.seconds {
+		return "", false
+	}
+	return last.sessionID, true
+}
+
+// secondTap applies WithDoubleTapStart. Returns true if a START press should
+// go ahead: the option is off, or the previous press was within the window.
+// Otherwise the press is remembered as the first tap.
+func (m *Microwave) secondTap() bool {
+	if m.doubleTapWindow <= 0 {
+		return true
+	}
+
+	now := m.clock.Now()
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if !m.firstTap.IsZero() && now.Sub(m.firstTap) <= m.doubleTapWindow {
+		m.firstTap = time.Time{}
+		return true
+	}
+	m.firstTap = now
+	return false
+}
 
+// start runs a cooking session for the entered time, blocking until the
+// countdown completes or ctx is canceled. The caller must have claimed the
+// cook with claimCook.
+func (m *Microwave) start(ctx context.Context, attrs []attribute.KeyValue) {
 	m.mu.Lock()
+	if digits := m.enteredDigits(); digits != m.digits {
+		// Move the entered digits from the seconds into the minutes
+		m.setDigits(digits)
+	}
 	seconds := m.totalSeconds()
+	digitCount := m.digitCount
 	m.mu.Unlock()
 
 	if seconds == 0 {
+		m.releaseCook()
 		m.logger.Warn("cannot start with zero time")
+		m.reject(ZeroTime, "cannot start with zero time")
 		return
 	}
 
-	// Start tracing span for cooking session
-	ctx, span := m.tracer.Start(ctx, "cooking_session")
-	defer span.End()
+	if m.partialEntry == RejectPartial && digitCount < 4 {
+		m.releaseCook()
+		m.logger.Warn("start rejected, enter all four digits", "digitCount", digitCount)
+		m.reject(PartialEntry, "start rejected, enter all four digits")
+		return
+	}
 
-	span.SetAttributes(
-		attribute.String("initial_display", m.Display()),
-		attribute.Int("duration_seconds", seconds),
-	)
+	if m.preStart != nil {
+		if err := m.preStart(seconds); err != nil {
+			m.releaseCook()
+			m.logger.Warn("start rejected by validator", "seconds", seconds, "error", err)
+			m.reject(ValidatorRejected, err.Error())
+			return
+		}
+	}
+
+	if m.normalizeOnStart {
+		m.normalizeDisplay(seconds)
+	}
+	m.cook(ctx, seconds, attrs)
+}
+
+// enteredDigits returns the entered digits as START reads them, moving a
+// partial entry into the minutes under AssumeMinutes (caller must hold lock)
+func (m *Microwave) enteredDigits() [4]int {
+	if m.partialEntry == AssumeMinutes && m.digitCount > 0 && m.digitCount <= 2 {
+		return [4]int{m.digits[2], m.digits[3], 0, 0}
+	}
+	return m.digits
+}
+
+// normalizeDisplay applies WithClockNormalizationOnStart, showing seconds as
+// a valid clock time if the entered digits aren't one
+func (m *Microwave) normalizeDisplay(seconds int) {
+	digits := secondsToDigits(seconds)
+	m.mu.Lock()
+	if digits == m.digits {
+		m.mu.Unlock()
+		return
+	}
+	entered := m.displayString()
+	m.setDigits(digits)
+	display := m.displayString()
+	m.mu.Unlock()
+
+	m.logger.Info("display normalized", "entered", entered, "display", display)
+}
+
+// QueueTime queues a cook of seconds to start automatically when the current
+// cook completes. It needs WithCookQueue and a cook in progress. Only one
+// cook can be queued, so a second is refused with QueueFull. Times beyond
+// 99:99 are clamped to the maximum. If the current cook is canceled, the
+// queued cook is dropped. Returns true if the time was queued.
+func (m *Microwave) QueueTime(seconds int) bool {
+	if !m.cookQueue {
+		m.logger.Warn("queue ignored, cook queue is off")
+		return false
+	}
+	if seconds <= 0 {
+		m.logger.Warn("cannot queue zero time", "seconds", seconds)
+		m.reject(ZeroTime, "cannot queue zero time")
+		return false
+	}
+	clamped := min(seconds, maxSeconds)
+
+	m.mu.Lock()
+	cooking, queued := m.isCooking, m.queued
+	if cooking && queued == 0 {
+		m.queued = clamped
+	}
+	m.mu.Unlock()
+
+	switch {
+	case !cooking:
+		m.logger.Warn("queue ignored, not cooking")
+		return false
+	case queued > 0:
+		m.logger.Warn("queue full, a cook is already queued", "queued", queued)
+		m.reject(QueueFull, "queue full, a cook is already queued")
+		return false
+	}
+
+	if clamped < seconds {
+		m.logger.Warn("queued time clamped to maximum", "seconds", seconds, "max", maxSeconds)
+	}
+	m.logger.Info("cook queued", "seconds", clamped)
+	return true
+}
+
+// StartUntil cooks until the microwave's clock reaches deadline, replacing
+// any entered digits with the time remaining. Each displayed second lasts one
+// logical second, and the cook is rounded up to a whole second so it never
+// ends early. Times beyond 99:99 are clamped to the maximum and deadlines that
+// have already passed are rejected. Blocks like PressStart.
+func (m *Microwave) StartUntil(ctx context.Context, deadline time.Time) {
+	if !m.claimCook() {
+		m.logger.WarnContext(ctx, "start ignored, already cooking")
+		m.reject(AlreadyCooking, "start ignored, already cooking")
+		return
+	}
+
+	until := deadline.Sub(m.clock.Now())
+	if until <= 0 {
+		m.releaseCook()
+		m.logger.WarnContext(ctx, "start rejected, deadline has passed", "deadline", deadline)
+		m.reject(DeadlinePassed, "start rejected, deadline has passed")
+		return
+	}
+	seconds := int((until + m.logicalSecond - 1) / m.logicalSecond)
+
+	m.mu.Lock()
+	m.setDigits(secondsToDigits(seconds))
+	m.digitCount = 4
+	m.mu.Unlock()
+
+	m.logger.InfoContext(ctx, "cooking until deadline", "deadline", deadline, "seconds", seconds)
+	m.cook(ctx, seconds, nil)
+}
+
+// StartToTemp cooks in probe mode: instead of a set time, it simulates the
+// food heating from 20°C at the WithHeatingRate, and stops once it reaches
+// targetC. While it cooks, the display shows the temperature, such as "45C",
+// in place of the time; DisplaySegments and RemainingSeconds still report
+// the time left. Targets at or below 20°C are rejected with TargetTooLow.
+// Blocks like PressStart.
+func (m *Microwave) StartToTemp(ctx context.Context, targetC int) {
+	if !m.claimCook() {
+		m.logger.WarnContext(ctx, "start ignored, already cooking")
+		m.reject(AlreadyCooking, "start ignored, already cooking")
+		return
+	}
+	if targetC <= ambientC {
+		m.releaseCook()
+		m.logger.WarnContext(ctx, "start rejected, target temperature too low", "target_c", targetC, "start_c", ambientC)
+		m.reject(TargetTooLow, "start rejected, target temperature too low")
+		return
+	}
+	seconds := int(math.Ceil(float64(targetC-ambientC) / m.heatingRate))
+
+	m.mu.Lock()
+	m.probe = probeCook{target: targetC, seconds: seconds}
+	m.setDigits(secondsToDigits(seconds))
+	m.digitCount = 4
+	m.mu.Unlock()
+
+	m.logger.InfoContext(ctx, "cooking to temperature", "target_c", targetC, "rate", m.heatingRate, "seconds", seconds)
+	next := m.cookOnce(ctx, seconds, nil)
+
+	// Back to showing the time, for a queued cook or the next entry
+	m.mu.Lock()
+	m.probe = probeCook{}
+	m.publishDisplay()
+	m.mu.Unlock()
+
+	if next > 0 {
+		m.logger.InfoContext(ctx, "starting queued cook", "seconds", next)
+		m.cook(ctx, next, nil)
+	}
+}
+
+// cook runs a cooking session for seconds, blocking until the countdown
+// completes or ctx is canceled, then runs any cook queued with QueueTime.
+// attrs are added to the first session's span and metric.
+func (m *Microwave) cook(ctx context.Context, seconds int, attrs []attribute.KeyValue) {
+	for seconds > 0 {
+		seconds = m.cookOnce(ctx, seconds, attrs)
+		attrs = nil
+		if seconds > 0 {
+			m.logger.InfoContext(ctx, "starting queued cook", "seconds", seconds)
+		}
+	}
+}
+
+// cookOnce runs a single cooking session. Returns the seconds of the cook
+// queued to follow it, or 0 if there is none.
+func (m *Microwave) cookOnce(ctx context.Context, seconds int, attrs []attribute.KeyValue) int {
+	if m.cookingBudget > 0 {
+		m.mu.Lock()
+		cooked := m.cooked
+		exhausted := time.Duration(cooked)*time.Second >= m.cookingBudget
+		if exhausted {
+			// Give up the caller's claim on the cook
+			m.starting = false
+		}
+		m.mu.Unlock()
+
+		if exhausted {
+			m.logger.WarnContext(ctx, "cooking budget exhausted", "cooked_seconds", cooked, "budget", m.cookingBudget)
+			if m.budgetRejections != nil {
+				m.budgetRejections.Add(ctx, 1)
+			}
+			m.reject(BudgetExhausted, "cooking budget exhausted")
+			return 0
+		}
+	}
+
+	sessionID := m.newID()
+
+	ctx, span := m.startSessionSpan(ctx, sessionID, seconds, attrs)
+	defer span.End()
 
 	// Record cooking session metric
 	if m.cookingSessions != nil {
-		m.cookingSessions.Add(ctx, 1)
+		m.cookingSessions.Add(ctx, 1, metric.WithAttributes(m.limitAttributes(attrs)...))
 	}
 
 	m.logger.InfoContext(ctx, "cooking started",
+		"session_id", sessionID,
 		"display", m.Display(),
 		"seconds", seconds,
 	)
 
+	// Wrap the context so CancelCook can stop this cook, even if ctx has
+	// no way to be canceled
+	cookCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	// countdown watches this cook's skip channel for the whole cook, so a
+	// skip is seen whenever it arrives
+	skip := newSkipSignal()
+
 	m.mu.Lock()
 	m.isCooking = true
+	m.starting = false
+	m.cookStart = m.clock.Now()
+	m.cancelCook = cancel
+	m.sessions++
+	m.skipCook = skip
+	m.remaining.Store(int64(seconds))
+	m.session = sessionID
+	m.holdUntil = time.Time{}
+	if m.startIdempotency > 0 {
+		set := attribute.NewSet(attrs...)
+		m.lastStart = startRecord{at: m.cookStart, attrs: set.Equivalent(), seconds: seconds, sessionID: sessionID}
+	}
 	m.mu.Unlock()
 
-	completed := m.countdown(ctx, seconds)
+	completed := m.countdown(cookCtx, seconds, skip.ch)
+	end := m.clock.Now()
 
 	m.mu.Lock()
-	m.isCooking = false
-	// Reset state for next use
-	// countdown may not have completed, leaving a non-zero time in the digits
-	m.digits = [4]int{0, 0, 0, 0}
-	m.digitCount = 0
+	// countdown leaves the last displayed time in remaining when canceled
+	remaining := m.remaining.Load()
+	m.remaining.Store(0)
+	elapsed := end.Sub(m.cookStart)
+	result := CookResult{
+		Completed:        completed,
+		SessionID:        sessionID,
+		RequestedSeconds: seconds,
+		ElapsedSeconds:   int(elapsed / m.logicalSecond),
+		EndedAt:          end,
+	}
+	m.lastCook = &result
+	// Only the countdown cooks; the warm-up before it doesn't count
+	m.cooked += int((elapsed - min(elapsed, max(m.warmup, 0))) / m.logicalSecond)
+	m.cookStart = time.Time{}
+	m.cancelCook = nil
+	m.skipCook = nil
+	m.session = ""
+	// A queued cook only follows a cook that completed
+	next, dropped := m.queued, 0
+	if !completed {
+		next, dropped = 0, m.queued
+	}
+	m.queued = 0
+	if completed && next == 0 && m.completionHold > 0 {
+		m.holdUntil = end.Add(m.completionHold)
+	}
+	if next == 0 && m.postCookDelay > 0 {
+		m.quietUntil = end.Add(m.postCookDelay)
+	}
+	if next > 0 {
+		// Show the queued time, and stay cooking so nothing else can start
+		// in between unless the budget will refuse the queued cook
+		m.setDigits(secondsToDigits(next))
+		m.digitCount = 4
+		m.isCooking = m.cookingBudget <= 0 || time.Duration(m.cooked)*time.Second < m.cookingBudget
+	} else {
+		// Reset state for next use
+		// countdown may not have completed, leaving a non-zero time in the digits
+		m.setDigits([4]int{0, 0, 0, 0})
+		m.digitCount = 0
+		m.isCooking = false
+	}
 	m.mu.Unlock()
 
 	if completed {
 		m.logger.InfoContext(ctx, "cooking complete")
 	} else {
-		m.logger.InfoContext(ctx, "cooking canceled")
+		m.logger.InfoContext(ctx, "cooking canceled", "remaining", remaining)
+		if dropped > 0 {
+			m.logger.InfoContext(ctx, "queued cook dropped", "seconds", dropped)
+		}
+		if m.remainingAtCancel != nil {
+			m.remainingAtCancel.Record(ctx, remaining)
+		}
+	}
+
+	if m.onComplete != nil {
+		m.onComplete(result)
+	}
+	return next
+}
+
+// startSessionSpan starts the cooking_session span. Attributes are only
+// computed when the span is recording, so a no-op tracer costs nothing extra.
+func (m *Microwave) startSessionSpan(ctx context.Context, sessionID string, seconds int, attrs []attribute.KeyValue) (context.Context, trace.Span) {
+	ctx, span := m.tracer.Start(ctx, "cooking_session")
+	if !span.IsRecording() {
+		return ctx, span
+	}
+
+	span.SetAttributes(
+		attribute.String("session_id", sessionID),
+		attribute.String("initial_display", m.Display()),
+		attribute.Int("duration_seconds", seconds),
+	)
+	span.SetAttributes(attrs...)
+	return ctx, span
+}
+
+// limitAttributes applies WithMetricAttributeLimit, replacing values beyond
+// each key's limit with "other"
+func (m *Microwave) limitAttributes(attrs []attribute.KeyValue) []attribute.KeyValue {
+	if m.attrLimit <= 0 || len(attrs) == 0 {
+		return attrs
+	}
+
+	limited := make([]attribute.KeyValue, len(attrs))
+	var capped []attribute.Key
+	m.mu.Lock()
+	if m.attrValues == nil {
+		m.attrValues = make(map[attribute.Key]map[string]bool)
+	}
+	for i, kv := range attrs {
+		seen := m.attrValues[kv.Key]
+		if seen == nil {
+			seen = make(map[string]bool)
+			m.attrValues[kv.Key] = seen
+		}
+		value := kv.Value.Emit()
+		switch {
+		case seen[value]:
+			limited[i] = kv
+		case len(seen) < m.attrLimit:
+			seen[value] = true
+			limited[i] = kv
+		default:
+			limited[i] = attribute.String(string(kv.Key), otherAttrValue)
+			capped = append(capped, kv.Key)
+		}
+	}
+	m.mu.Unlock()
+
+	for _, key := range capped {
+		m.logger.Warn("metric attribute limit reached, recording as other", "key", key, "limit", m.attrLimit)
+	}
+	return limited
+}
+
+// reject counts a refused press or start in the rejections metric and
+// reports it to the rejection handler, if any.
+// Must be called without the lock held.
+func (m *Microwave) reject(reason RejectReason, detail string) {
+	if m.rejections != nil {
+		m.rejections.Add(context.Background(), 1,
+			metric.WithAttributes(attribute.String("reason", reason.String())),
+		)
 	}
+	if m.onReject != nil {
+		m.onReject(reason, detail)
+	}
+}
+
+// CancelCook cancels the cook in progress without needing the context it
+// was started with. Returns true if a cook was canceled, false if there was
+// nothing to cancel. Safe to call repeatedly and from any goroutine.
+func (m *Microwave) CancelCook() bool {
+	m.mu.Lock()
+	cancel := m.cancelCook
+	m.cancelCook = nil
+	m.mu.Unlock()
+
+	if cancel == nil {
+		return false
+	}
+
+	m.logger.Info("cook canceled by request")
+	cancel()
+	return true
+}
+
+// SkipToEnd finishes the cook in progress right away. Unlike CancelCook the
+// cook ends as completed: the display shows 00:00, "cooking complete" is
+// logged and the result reports Completed. Returns true if a cook was
+// skipped, false if there was nothing to skip.
+func (m *Microwave) SkipToEnd() bool {
+	m.mu.Lock()
+	skip := m.skipCook
+	m.mu.Unlock()
+
+	remaining := m.remaining.Load()
+	if skip == nil || !skip.fire() {
+		return false
+	}
+
+	// countdown zeroes remaining along with the digits as the cook ends
+	m.logger.Info("cook skipped to end", "remaining", remaining)
+	return true
+}
+
+// skipSignal finishes one cook early. Its channel is closed at most once,
+// however many times SkipToEnd is called.
+type skipSignal struct {
+	ch   chan struct{}
+	once sync.Once
+}
+
+func newSkipSignal() *skipSignal {
+	return &skipSignal{ch: make(chan struct{})}
+}
+
+// fire closes the channel, returning false if it was already closed
+func (s *skipSignal) fire() bool {
+	fired := false
+	s.once.Do(func() {
+		fired = true
+		close(s.ch)
+	})
+	return fired
 }
 
 // totalSeconds calculates total seconds from the digit display
 // Must be called with lock held
 func (m *Microwave) totalSeconds() int {
-	minutes := m.digits[0]*10 + m.digits[1]
-	seconds := m.digits[2]*10 + m.digits[3]
-	return minutes*60 + seconds
+	return digitsToSeconds(m.digits)
 }
 
 // countdown runs the cooking countdown. Returns true if completed, false if canceled.
+// Closing skip ends it as completed; a nil skip never does.
 // I recognize that the assignment stated that the microwave could not be stopped
 // once started. This function allows for returning false for canceled for more
 // efficient testing of edge cases and use with a sample driver program.
-func (m *Microwave) countdown(ctx context.Context, seconds int) bool {
-	for seconds > 0 {
-		// Convert seconds back to display format
-		mins := seconds / 60
-		secs := seconds % 60
-
-		// if mins > 99, add the extra seconds to secs
-		// we shouldn't run into a situation where
-		// we don't have enough room to display the
-		// digits because we did input checking in
-		// PressDigit to make sure the largest value
-		// we accepted was 99:99. if we do find ourself
-		// in a strange situation, then log a warning
-		// and print **:** ?
-		overflowMins := mins - 99
-		if overflowMins == 1 {
-			mins = 99
-			secs = secs + overflowMins*60
-		} else if overflowMins > 1 {
-			// Trouble
-			m.logger.WarnContext(ctx, "unexpected overflowMins > 1", "overflowMins", overflowMins)
-			mins = 99
-			secs = 99
+func (m *Microwave) countdown(ctx context.Context, seconds int, skip <-chan struct{}) bool {
+	// PressDigit limits entry to 99:99, so we shouldn't be asked to count
+	// down from more than the display can show. If we are, clamp once here
+	// rather than on every tick.
+	if seconds > maxSeconds {
+		m.logger.WarnContext(ctx, "cooking time clamped to maximum", "seconds", seconds, "max", maxSeconds)
+		if m.clampedDurations != nil {
+			m.clampedDurations.Add(ctx, 1)
+		}
+		seconds = maxSeconds
+	}
+	// Nothing should ask for a negative time either, but if some arithmetic
+	// bug does, finish at 00:00 instead of counting below zero
+	if seconds < 0 {
+		m.logger.WarnContext(ctx, "negative cooking time clamped to zero", "seconds", seconds)
+		seconds = 0
+	}
+
+	if m.warmup > 0 {
+		m.mu.Lock()
+		m.setDigits(secondsToDigits(seconds))
+		m.remaining.Store(int64(seconds))
+		display := m.displayString()
+		m.mu.Unlock()
+
+		m.logger.InfoContext(ctx, "warming up", "display", display, "warmup", m.warmup)
+		select {
+		case <-ctx.Done():
+			return false
+		case <-skip:
+			seconds = 0
+		case <-m.clock.After(m.warmup):
 		}
+	}
 
+	var lastFrame time.Time
+	lastBeat := m.clock.Now()
+	prevMinutes := -1
+	for seconds > 0 {
 		// update the digits in the display
 		// generate a new string from the display digits
+		digits := secondsToDigits(seconds)
 		m.mu.Lock()
-		m.digits[0] = mins / 10
-		m.digits[1] = mins % 10
-		m.digits[2] = secs / 10
-		m.digits[3] = secs % 10
+		// remaining changes with the digits, under the lock, so a Status
+		// snapshot never pairs one second's display with another's remaining
+		m.setDigits(digits)
+		m.remaining.Store(int64(seconds))
 		display := m.displayString()
+		interval := m.tickInterval()
 		m.mu.Unlock()
 
-		m.logger.DebugContext(ctx, "tick", "display", display, "remaining", seconds)
-		fmt.Print(display + "\r\n")
+		m.logger.Log(ctx, m.tickLogLevel, "tick", "display", display, "remaining", seconds)
+		minutes := digits[0]*10 + digits[1]
+		if prevMinutes >= 0 && minutes < prevMinutes {
+			m.minuteRollover(ctx, display, minutes)
+		}
+		prevMinutes = minutes
+		now := m.clock.Now()
+		if m.heartbeat > 0 && now.Sub(lastBeat) >= m.heartbeat {
+			m.logger.InfoContext(ctx, "cooking in progress", "display", display, "remaining", seconds)
+			lastBeat = now
+		}
+		if lastFrame.IsZero() || now.Sub(lastFrame) >= m.displayThrottle {
+			fmt.Fprint(m.out, display+"\r\n")
+			lastFrame = now
+		}
 
 		// Wait for 1 second or context cancellation
 		select {
 		case <-ctx.Done():
 			return false
-		case <-time.After(1 * time.Second):
+		case <-skip:
+			seconds = 0
+		case <-m.clock.After(interval):
 			seconds--
 		}
 	}
 
-	// Print final 00:00
+	// Print final 00:00, or the WithFinalFrame rendering of it
 	m.mu.Lock()
-	m.digits = [4]int{0, 0, 0, 0}
+	m.setDigits([4]int{0, 0, 0, 0})
+	m.remaining.Store(0)
 	display := m.displayString()
 	m.mu.Unlock()
-	fmt.Print(display + "\r\n")
-	m.logger.DebugContext(ctx, "tick", "display", display, "remaining", seconds)
+	if m.finalFrame != nil {
+		display = m.finalFrame(m)
+	}
+	fmt.Fprint(m.out, display+"\r\n")
+	m.logger.Log(ctx, m.tickLogLevel, "tick", "display", display, "remaining", seconds)
 	return true
 }
+
+// minuteRollover reports that the displayed minutes dropped to minutes.
+// Must be called without the lock held.
+func (m *Microwave) minuteRollover(ctx context.Context, display string, minutes int) {
+	m.logger.InfoContext(ctx, "minute rollover", "display", display, "minutes", minutes)
+	trace.SpanFromContext(ctx).AddEvent("minute_rollover",
+		trace.WithAttributes(attribute.Int("minutes", minutes)),
+	)
+	if m.onMinuteRollover != nil {
+		m.onMinuteRollover(minutes)
+	}
+}
+
+// tickInterval returns how long the next tick lasts: the logical second,
+// randomly adjusted when tick jitter is on. Caller must hold m.mu.
+func (m *Microwave) tickInterval() time.Duration {
+	if m.rng == nil {
+		return m.logicalSecond
+	}
+	offset := (m.rng.Float64()*2 - 1) * m.tickJitter
+	return time.Duration(float64(m.logicalSecond) * (1 + offset))
+}
@@ -3,13 +3,21 @@ package microwave
 import (
 	"bytes"
 	"context"
+	"encoding/json"
+	"fmt"
+	"io"
 	"log/slog"
+	"runtime"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
+	"github.com/dskard/megawave/internal/telemetry"
+
 	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
 	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
 	"go.opentelemetry.io/otel/sdk/metric/metricdata"
 	"go.opentelemetry.io/otel/sdk/trace"
@@ -64,6 +72,9 @@ func TestNew(t *testing.T) {
 	if m.cookingSessions == nil {
 		t.Error("expected cookingSessions to be non-nil")
 	}
+	if m.clampedDurations == nil {
+		t.Error("expected clampedDurations to be non-nil")
+	}
 }
 
 // TestNewWithLogger verifies that WithLogger option sets the logger correctly.
@@ -116,6 +127,184 @@ func TestNewWithMeter(t *testing.T) {
 	}
 }
 
+// TestNewWithInstrumentationName verifies that spans carry the configured instrumentation scope.
+// Test logic: Records spans in memory from a microwave with the default name and one with a
+// custom name, and checks each span's instrumentation scope name.
+func TestNewWithInstrumentationName(t *testing.T) {
+	tests := []struct {
+		name string
+		opts []Option
+		want string
+	}{
+		{"default", nil, "megawave"},
+		{"configured", []Option{WithInstrumentationName("kitchen.microwave")}, "kitchen.microwave"},
+		{"empty keeps default", []Option{WithInstrumentationName("")}, "megawave"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Option order doesn't matter, so set the name after in-memory tracing
+			m := New(append([]Option{WithInMemoryTracing()}, tt.opts...)...)
+			_, span := m.startSessionSpan(context.Background(), "session", 10, nil)
+			span.End()
+
+			spans := m.RecordedSpans()
+			if len(spans) != 1 {
+				t.Fatalf("recorded %d spans, want 1", len(spans))
+			}
+			if got := spans[0].InstrumentationScope.Name; got != tt.want {
+				t.Errorf("instrumentation scope = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+// TestNewWithClock verifies that WithClock option sets the clock correctly.
+// Test logic: Creates a fake clock and passes it via WithClock option,
+// then verifies the Microwave's clock field points to the supplied clock.
+func TestNewWithClock(t *testing.T) {
+	clock := newFakeClock()
+	m := New(WithClock(clock))
+
+	// Check clock is set to user supplied clock
+	if m.clock != clock {
+		t.Error("expected clock to be set to user supplied clock")
+	}
+}
+
+// TestNewWithOnComplete verifies that WithOnComplete option sets the completion callback.
+// Test logic: Passes a callback via WithOnComplete and verifies the onComplete field is set.
+func TestNewWithOnComplete(t *testing.T) {
+	m := New(WithOnComplete(func(CookResult) {}))
+
+	// Check callback is set
+	if m.onComplete == nil {
+		t.Error("expected onComplete to be set")
+	}
+}
+
+// TestNewWithLogicalSecond verifies that WithLogicalSecond sets the logical second.
+// Test logic: Checks the default is one second, that a positive duration is applied,
+// and that a non-positive duration is ignored.
+func TestNewWithLogicalSecond(t *testing.T) {
+	// Default is one real second
+	if got := New().logicalSecond; got != time.Second {
+		t.Errorf("default logicalSecond = %v, want 1s", got)
+	}
+
+	// Positive durations are applied
+	if got := New(WithLogicalSecond(100 * time.Millisecond)).logicalSecond; got != 100*time.Millisecond {
+		t.Errorf("logicalSecond = %v, want 100ms", got)
+	}
+
+	// Non-positive durations are ignored
+	if got := New(WithLogicalSecond(0)).logicalSecond; got != time.Second {
+		t.Errorf("logicalSecond = %v, want 1s when set to 0", got)
+	}
+}
+
+// Accessor Test Cases
+
+// TestTickInterval verifies that TickInterval reports the configured logical second.
+// Test logic: Checks the default is one second and that WithLogicalSecond changes it.
+func TestTickInterval(t *testing.T) {
+	if got := New().TickInterval(); got != time.Second {
+		t.Errorf("default TickInterval() = %v, want 1s", got)
+	}
+	if got := New(WithLogicalSecond(250 * time.Millisecond)).TickInterval(); got != 250*time.Millisecond {
+		t.Errorf("TickInterval() = %v, want 250ms", got)
+	}
+}
+
+// TestMaxDuration verifies that MaxDuration reports the 99:99 display limit.
+// Test logic: Checks MaxDuration is 99 minutes 99 seconds and does not change with
+// the logical second, since it is measured in displayed seconds.
+func TestMaxDuration(t *testing.T) {
+	want := 99*time.Minute + 99*time.Second
+	if got := New().MaxDuration(); got != want {
+		t.Errorf("MaxDuration() = %v, want %v", got, want)
+	}
+	if got := New(WithLogicalSecond(100 * time.Millisecond)).MaxDuration(); got != want {
+		t.Errorf("MaxDuration() = %v with a fast logical second, want %v", got, want)
+	}
+}
+
+// TestOptions verifies that Options summarizes the applied settings.
+// Test logic: Checks the defaults of a bare microwave, then builds one with several options
+// and compares its full summary against the expected values.
+func TestOptions(t *testing.T) {
+	defaults := OptionsSummary{
+		TickInterval:        time.Second,
+		MaxDuration:         99*time.Minute + 99*time.Second,
+		Separator:           ":",
+		HeatingRate:         1,
+		TickLogLevel:        slog.LevelDebug,
+		InstrumentationName: "megawave",
+	}
+	if got := New().Options(); got != defaults {
+		t.Errorf("default Options() = %+v, want %+v", got, defaults)
+	}
+
+	m := New(
+		WithLogicalSecond(100*time.Millisecond),
+		WithRandomizedTickJitter(0.2),
+		WithJitterSeed(7),
+		WithSeparator(""),
+		WithHeartbeat(30*time.Second),
+		WithPartialEntryPolicy(AssumeMinutes),
+		WithAutoStartOnFull(true),
+		WithDoubleTapStart(time.Second),
+		WithCookingBudget(time.Hour),
+		WithMetricAttributeLimit(10),
+		WithInMemoryTracing(),
+		WithOnComplete(func(CookResult) {}),
+		WithInstrumentationName("kitchen"),
+		WithReadMetrics(true),
+	)
+	want := OptionsSummary{
+		TickInterval:         100 * time.Millisecond,
+		MaxDuration:          99*time.Minute + 99*time.Second,
+		TickJitter:           0.2,
+		JitterSeeded:         true,
+		Separator:            "",
+		Heartbeat:            30 * time.Second,
+		PartialEntry:         AssumeMinutes,
+		AutoStartOnFull:      true,
+		DoubleTapWindow:      time.Second,
+		CookingBudget:        time.Hour,
+		MetricAttributeLimit: 10,
+		InMemoryTracing:      true,
+		OnComplete:           true,
+		InstrumentationName:  "kitchen",
+		HeatingRate:          1,
+		TickLogLevel:         slog.LevelDebug,
+		ReadMetrics:          true,
+	}
+	if got := m.Options(); got != want {
+		t.Errorf("Options() = %+v, want %+v", got, want)
+	}
+}
+
+// Logger Test Cases
+
+// TestLogger verifies that Logger returns the logger passed via WithLogger.
+// Test logic: Creates a Microwave with a custom logger and checks Logger returns it,
+// then checks a default Microwave returns a non-nil logger.
+func TestLogger(t *testing.T) {
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewJSONHandler(&buf, nil))
+
+	// Returns the user supplied logger
+	if got := New(WithLogger(logger)).Logger(); got != logger {
+		t.Error("Logger() should return the logger passed via WithLogger")
+	}
+
+	// Returns the default logger when none is supplied
+	if New().Logger() == nil {
+		t.Error("Logger() should not return nil")
+	}
+}
+
 // displayString Test Cases
 
 // TestDisplayString verifies that displayString formats digits as MM:SS.
@@ -162,6 +351,103 @@ func TestDisplay(t *testing.T) {
 	}
 }
 
+// TestDisplayWithoutSeparator verifies the bare four-digit display and its round-trip parse.
+// Test logic: With an empty separator, enters 1,3,0 and checks Display shows "0130", the
+// colon is reported unlit, and parsing the display with the microwave's separator gives
+// back 90 seconds.
+func TestDisplayWithoutSeparator(t *testing.T) {
+	m := New(WithSeparator(""))
+	m.PressDigit(1)
+	m.PressDigit(3)
+	m.PressDigit(0)
+
+	display := m.Display()
+	if display != "0130" {
+		t.Errorf("Display() = %q, want 0130", display)
+	}
+	if m.ColonLit() {
+		t.Error("ColonLit() = true, want false without a separator")
+	}
+
+	seconds, err := ParseDisplayWithSeparator(display, m.Separator())
+	if err != nil || seconds != 90 {
+		t.Errorf("ParseDisplayWithSeparator(%q) = %d, %v; want 90", display, seconds, err)
+	}
+}
+
+// TestDisplayCompactSubMinute verifies that WithCompactSubMinute drops zero minutes from the display.
+// Test logic: Enters 4,5 with the option on and off and checks the display shows ":45" and
+// "00:45" respectively, then checks a time of a minute or more keeps all four digits.
+func TestDisplayCompactSubMinute(t *testing.T) {
+	tests := []struct {
+		name     string
+		compact  bool
+		digits   []int
+		expected string
+	}{
+		{"enabled under a minute", true, []int{4, 5}, ":45"},
+		{"disabled under a minute", false, []int{4, 5}, "00:45"},
+		{"enabled over a minute", true, []int{1, 3, 0}, "01:30"},
+		{"enabled when idle", true, nil, ":00"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := New(WithCompactSubMinute(tt.compact), WithOutput(io.Discard))
+			for _, d := range tt.digits {
+				m.PressDigit(d)
+			}
+			if got := m.Display(); got != tt.expected {
+				t.Errorf("Display() = %q, want %q", got, tt.expected)
+			}
+		})
+	}
+}
+
+// DisplaySegments Test Cases
+
+// TestDisplaySegments verifies that DisplaySegments returns the entered digits.
+// Test logic: Presses digit sequences and checks DisplaySegments returns the digits
+// in [M1, M2, S1, S2] order, matching Display.
+func TestDisplaySegments(t *testing.T) {
+	tests := []struct {
+		digits   []int
+		expected [4]int
+	}{
+		{[]int{}, [4]int{0, 0, 0, 0}},
+		{[]int{7}, [4]int{0, 0, 0, 7}},
+		{[]int{1, 3, 5}, [4]int{0, 1, 3, 5}},
+		{[]int{1, 2, 3, 4}, [4]int{1, 2, 3, 4}},
+	}
+
+	for _, tt := range tests {
+		m := New()
+		for _, d := range tt.digits {
+			m.PressDigit(d)
+		}
+		if got := m.DisplaySegments(); got != tt.expected {
+			t.Errorf("after pressing %v, DisplaySegments() = %v, want %v", tt.digits, got, tt.expected)
+		}
+	}
+}
+
+// TestColonLit verifies that the colon is lit whether idle or cooking.
+// Test logic: Checks ColonLit on a new microwave, then again with isCooking set.
+func TestColonLit(t *testing.T) {
+	m := New()
+	if !m.ColonLit() {
+		t.Error("ColonLit() = false, want true when idle")
+	}
+
+	m.mu.Lock()
+	m.isCooking = true
+	m.mu.Unlock()
+
+	if !m.ColonLit() {
+		t.Error("ColonLit() = false, want true while cooking")
+	}
+}
+
 // IsCooking Tests Cases
 
 // TestIsCooking verifies that IsCooking returns the correct cooking state.
@@ -190,6 +476,192 @@ func TestIsCooking(t *testing.T) {
 	}
 }
 
+// IsValidClockTime Test Cases
+
+// TestIsValidClockTime verifies that only displays with seconds below 60 are valid clock times.
+// Test logic: Uses table-driven tests to enter different digit sequences and checks
+// IsValidClockTime against whether the seconds shown are below 60.
+func TestIsValidClockTime(t *testing.T) {
+	tests := []struct {
+		digits   []int
+		expected bool
+	}{
+		{[]int{}, true},
+		{[]int{0, 0, 7, 5}, false},
+		{[]int{0, 1, 3, 0}, true},
+		{[]int{5, 9}, true},
+		{[]int{6, 0}, false},
+	}
+
+	for _, tt := range tests {
+		m := New(WithOutput(io.Discard))
+		for _, d := range tt.digits {
+			m.PressDigit(d)
+		}
+		if got := m.IsValidClockTime(); got != tt.expected {
+			t.Errorf("IsValidClockTime() = %v with %s displayed, want %v", got, m.Display(), tt.expected)
+		}
+	}
+}
+
+// ElapsedSeconds Test Cases
+
+// TestElapsedSecondsWhenIdle verifies that ElapsedSeconds returns 0 when not cooking.
+// Test logic: Enters a time without starting, advances the fake clock, and checks
+// ElapsedSeconds still reports 0.
+func TestElapsedSecondsWhenIdle(t *testing.T) {
+	clock := newFakeClock()
+	m := New(WithClock(clock))
+
+	// Enter a time but don't start cooking
+	m.PressDigit(5)
+	clock.Advance(3 * time.Second)
+
+	if got := m.ElapsedSeconds(); got != 0 {
+		t.Errorf("ElapsedSeconds() = %d, want 0", got)
+	}
+}
+
+// Status Test Cases
+
+// TestStatusIdle verifies the status of an idle microwave with digits entered.
+// Test logic: Enters 1,3,0 and checks Status reports the display, digit count, and zero
+// timing while not cooking.
+func TestStatusIdle(t *testing.T) {
+	m := New()
+	m.PressDigit(1)
+	m.PressDigit(3)
+	m.PressDigit(0)
+
+	want := Status{Display: "01:30", DigitCount: 3}
+	if got := m.Status(); got != want {
+		t.Errorf("Status() = %+v, want %+v", got, want)
+	}
+}
+
+// TestReadMetrics verifies that Display and Status reads are counted per method only when enabled.
+// Test logic: Reads a microwave built with WithReadMetrics three times through Display and
+// twice through Status and checks the display_reads counter per method, then checks a
+// microwave without the option records no display_reads counter at all.
+func TestReadMetrics(t *testing.T) {
+	reader := sdkmetric.NewManualReader()
+	mp := sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))
+	m := New(WithMeter(mp.Meter("test")), WithReadMetrics(true))
+
+	for range 3 {
+		m.Display()
+	}
+	for range 2 {
+		m.Status()
+	}
+
+	var rm metricdata.ResourceMetrics
+	if err := reader.Collect(context.Background(), &rm); err != nil {
+		t.Fatalf("failed to collect metrics: %v", err)
+	}
+	reads := map[string]int64{}
+	for _, sm := range rm.ScopeMetrics {
+		for _, md := range sm.Metrics {
+			if md.Name != "microwave.display_reads" {
+				continue
+			}
+			for _, dp := range md.Data.(metricdata.Sum[int64]).DataPoints {
+				method, _ := dp.Attributes.Value("method")
+				reads[method.AsString()] += dp.Value
+			}
+		}
+	}
+	if len(reads) != 2 || reads["display"] != 3 || reads["status"] != 2 {
+		t.Errorf("display_reads = %v, want display:3 status:2", reads)
+	}
+
+	// Off by default: reads aren't counted
+	reader = sdkmetric.NewManualReader()
+	mp = sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))
+	m = New(WithMeter(mp.Meter("test")))
+	m.Display()
+	m.Status()
+	if err := reader.Collect(context.Background(), &rm); err != nil {
+		t.Fatalf("failed to collect metrics: %v", err)
+	}
+	if n := counterValue(rm, "microwave.display_reads"); n != 0 {
+		t.Errorf("display_reads = %d without WithReadMetrics, want 0", n)
+	}
+}
+
+// History Test Cases
+
+// TestHistory verifies that History lists the most recent presses in order with their timestamps.
+// Test logic: Keeps 4 presses on a fake clock, presses 1, 2, 3, backspace and 4 a second apart,
+// and checks History holds the last 4 oldest first with their sequence numbers and times.
+// Then checks a microwave without WithHistorySize keeps nothing.
+func TestHistory(t *testing.T) {
+	clock := newFakeClock()
+	start := clock.Now()
+	m := New(WithClock(clock), WithOutput(io.Discard), WithHistorySize(4))
+
+	for _, press := range []func(){
+		func() { m.PressDigit(1) },
+		func() { m.PressDigit(2) },
+		func() { m.PressDigit(3) },
+		m.PressBackspace,
+		func() { m.PressDigit(4) },
+	} {
+		press()
+		clock.Advance(time.Second)
+	}
+
+	// The first press was overwritten once the buffer filled
+	want := []PressRecord{
+		{Seq: 2, Button: "digit", Digit: 2, At: start.Add(1 * time.Second)},
+		{Seq: 3, Button: "digit", Digit: 3, At: start.Add(2 * time.Second)},
+		{Seq: 4, Button: "backspace", At: start.Add(3 * time.Second)},
+		{Seq: 5, Button: "digit", Digit: 4, At: start.Add(4 * time.Second)},
+	}
+	got := m.History()
+	if len(got) != len(want) {
+		t.Fatalf("History() = %+v, want %+v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("History()[%d] = %+v, want %+v", i, got[i], want[i])
+		}
+	}
+
+	// Off by default
+	m = New(WithOutput(io.Discard))
+	m.PressDigit(1)
+	if got := m.History(); got != nil {
+		t.Errorf("History() = %+v without WithHistorySize, want nil", got)
+	}
+}
+
+// RemainingSeconds Test Cases
+
+// TestRemainingSecondsWhenIdle verifies that RemainingSeconds returns 0 when not cooking.
+// Test logic: Enters a time without starting and checks RemainingSeconds reports 0.
+func TestRemainingSecondsWhenIdle(t *testing.T) {
+	m := New()
+	m.PressDigit(5)
+
+	if got := m.RemainingSeconds(); got != 0 {
+		t.Errorf("RemainingSeconds() = %d, want 0", got)
+	}
+}
+
+// EstimatedCompletion Test Cases
+
+// TestEstimatedCompletionWhenIdle verifies that EstimatedCompletion reports nothing when not cooking.
+// Test logic: Enters a time without starting and checks EstimatedCompletion returns false.
+func TestEstimatedCompletionWhenIdle(t *testing.T) {
+	m := New()
+	m.PressDigit(5)
+
+	if got, ok := m.EstimatedCompletion(); ok {
+		t.Errorf("EstimatedCompletion() = %v, true; want false when idle", got)
+	}
+}
+
 // PressDigit Test Cases
 
 // TestPressDigitInvalidDigit verifies that invalid digits (< 0 or > 9) are ignored.
@@ -335,76 +807,364 @@ func TestPressDigitShiftsLeft(t *testing.T) {
 	}
 }
 
-// PressStart Test Cases
-
-// TestPressStartIgnoresPressesWhileCooking verifies that PressStart is ignored while cooking.
-// Test logic: Sets up time, sets isCooking=true, calls PressStart, then verifies
-// "start ignored, already cooking" warning appears and cooking state is unchanged.
-func TestPressStartIgnoresPressesWhileCooking(t *testing.T) {
+// TestPressDigitInputRateLimit verifies that digit presses beyond WithInputRateLimit are refused.
+// Test logic: With a limit of 3 presses per second on a fake clock, presses 5 digits at once and
+// checks only the first 3 are entered and the other 2 are reported as RateLimited and counted.
+// Then advances 400ms, enough for one more press, and checks the next press is entered and
+// the one after is refused again.
+func TestPressDigitInputRateLimit(t *testing.T) {
 	var buf bytes.Buffer
-	logger := slog.New(slog.NewJSONHandler(&buf, nil))
-
-	m := New(WithLogger(logger))
+	var got []rejection
+	clock := newFakeClock()
+	reader := sdkmetric.NewManualReader()
+	mp := sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))
+	m := New(
+		WithClock(clock),
+		WithOutput(io.Discard),
+		WithLogger(slog.New(slog.NewJSONHandler(&buf, nil))),
+		WithMeter(mp.Meter("test")),
+		WithInputRateLimit(3, time.Second),
+		recordRejections(&got),
+	)
+
+	// A burst of 5 lets the first 3 through
+	for d := range 5 {
+		m.PressDigit(d + 1)
+	}
+	if m.Display() != "01:23" {
+		t.Errorf("Display() = %s after a burst, want 01:23", m.Display())
+	}
 
-	// Make sure there is time on the clock
-	// so we avoid warning about starting with zero time.
+	// 400ms refills one press, but not two
+	clock.Advance(400 * time.Millisecond)
 	m.PressDigit(4)
-	if got := m.Display(); got != "00:04" {
-		t.Errorf("Display() = %s, want 00:04", got)
+	m.PressDigit(5)
+	if m.Display() != "12:34" {
+		t.Errorf("Display() = %s after refilling, want 12:34", m.Display())
 	}
 
-	// Set cooking to true
-	m.mu.Lock()
-	m.isCooking = true
-	m.mu.Unlock()
-
-	// press start
-	m.PressStart(context.Background())
-
-	logs := buf.String()
-	if !strings.Contains(logs, "start ignored, already cooking") {
-		t.Error("expected 'start ignored, already cooking' warning in logs")
+	if len(got) != 3 {
+		t.Fatalf("rejections = %v, want 3", got)
+	}
+	for _, r := range got {
+		if r.reason != RateLimited {
+			t.Errorf("rejection reason = %v, want RateLimited", r.reason)
+		}
+	}
+	if n := strings.Count(buf.String(), "input rate limited"); n != 3 {
+		t.Errorf("logged %d rate limited presses, want 3", n)
 	}
 
-	if m.IsCooking() != true {
-		t.Error("should still be cooking after pressing start while cooking")
+	var rm metricdata.ResourceMetrics
+	if err := reader.Collect(context.Background(), &rm); err != nil {
+		t.Fatalf("failed to collect metrics: %v", err)
+	}
+	if n := counterValue(rm, "microwave.rate_limited_presses"); n != 3 {
+		t.Errorf("rate_limited_presses = %d, want 3", n)
 	}
 }
 
-// TestPressStartWithZeroTime verifies that PressStart is ignored when no time is set.
-// Test logic: Calls PressStart without entering any digits, verifies "cannot start
-// with zero time" warning appears and cooking does not start.
-func TestPressStartWithZeroTime(t *testing.T) {
-	var buf bytes.Buffer
-	logger := slog.New(slog.NewJSONHandler(&buf, nil))
+// PressBackspace Test Cases
 
-	m := New(WithLogger(logger))
+// TestPressBackspaceErasesLastDigit verifies that backspace undoes the last digit entered.
+// Test logic: Enters 1,2,3, presses backspace and checks the display shows 00:12, then
+// enters 4 and checks 01:24. Backspacing everything and once more leaves 00:00 and the
+// digit count at zero.
+func TestPressBackspaceErasesLastDigit(t *testing.T) {
+	m := New(WithOutput(io.Discard))
+	m.PressDigit(1)
+	m.PressDigit(2)
+	m.PressDigit(3)
 
-	// Don't enter any digits, try to start
-	m.PressStart(context.Background())
+	m.PressBackspace()
+	if got := m.Display(); got != "00:12" {
+		t.Errorf("Display() = %s after backspace, want 00:12", got)
+	}
 
-	logs := buf.String()
-	if !strings.Contains(logs, "cannot start with zero time") {
-		t.Error("expected 'cannot start with zero time' warning")
+	// The erased position can be re-entered
+	m.PressDigit(4)
+	if got := m.Display(); got != "01:24" {
+		t.Errorf("Display() = %s, want 01:24", got)
 	}
 
-	if m.IsCooking() {
-		t.Error("should not be cooking with zero time")
+	// Erasing past the first digit is harmless
+	for range 4 {
+		m.PressBackspace()
+	}
+	if got := m.Display(); got != "00:00" {
+		t.Errorf("Display() = %s after erasing everything, want 00:00", got)
+	}
+	m.mu.Lock()
+	count := m.digitCount
+	m.mu.Unlock()
+	if count != 0 {
+		t.Errorf("digitCount = %d, want 0", count)
 	}
 }
 
-// totalSeconds test cases
+// TestPressBackspaceFreesDigitSlot verifies that backspace lets a fifth digit be corrected.
+// Test logic: Enters four digits, presses backspace, enters a new digit, and checks the
+// display shows the corrected time instead of rejecting the digit.
+func TestPressBackspaceFreesDigitSlot(t *testing.T) {
+	m := New(WithOutput(io.Discard))
+	for _, d := range []int{1, 2, 3, 4} {
+		m.PressDigit(d)
+	}
 
-// TestTotalSeconds verifies that totalSeconds correctly converts digits to seconds.
-// Test logic: Sets various digit combinations and verifies totalSeconds returns
-// the correct number of seconds: 00:00=0, 00:05=5, 01:30=90, 99:99=6039.
-func TestTotalSeconds(t *testing.T) {
-	m := New()
+	m.PressBackspace()
+	m.PressDigit(9)
 
-	// 00:00 = 0 seconds
-	m.mu.Lock()
-	m.digits = [4]int{0, 0, 0, 0}
-	s := m.totalSeconds()
+	if got := m.Display(); got != "12:39" {
+		t.Errorf("Display() = %s, want 12:39", got)
+	}
+}
+
+// TestPressBackspaceIgnoredWhileCooking verifies that backspace does not change the display while cooking.
+// Test logic: Enters 4, sets isCooking, presses backspace, and checks the display still
+// shows 00:04 and a warning was logged.
+func TestPressBackspaceIgnoredWhileCooking(t *testing.T) {
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewJSONHandler(&buf, nil))
+	m := New(WithLogger(logger), WithOutput(io.Discard))
+	m.PressDigit(4)
+
+	m.mu.Lock()
+	m.isCooking = true
+	m.mu.Unlock()
+	m.PressBackspace()
+
+	if got := m.Display(); got != "00:04" {
+		t.Errorf("Display() = %s, want 00:04", got)
+	}
+	if !strings.Contains(buf.String(), "backspace ignored while cooking") {
+		t.Error("expected 'backspace ignored while cooking' warning in logs")
+	}
+}
+
+// PressClear Test Cases
+
+// TestPressClearWhenIdle verifies that PressClear erases the entered digits.
+// Test logic: Enters 1,2,3, presses clear, and checks the display is 00:00 with no digits
+// entered and "cleared" logged without a canceled cook.
+func TestPressClearWhenIdle(t *testing.T) {
+	var buf bytes.Buffer
+	m := New(WithLogger(slog.New(slog.NewJSONHandler(&buf, nil))), WithOutput(io.Discard))
+	m.PressDigit(1)
+	m.PressDigit(2)
+	m.PressDigit(3)
+
+	m.PressClear(context.Background())
+
+	if got := m.Status(); got.Display != "00:00" || got.DigitCount != 0 {
+		t.Errorf("Status() = %+v after clear, want 00:00 with no digits", got)
+	}
+	if !strings.Contains(buf.String(), `"msg":"cleared","canceled_cook":false`) {
+		t.Errorf("expected 'cleared' without a canceled cook in logs, got %s", buf.String())
+	}
+}
+
+// SetDuration Test Cases
+
+// TestSetDuration verifies that SetDuration shows the normalized time and replaces earlier entry.
+// Test logic: Enters a digit, then sets 90s, 1.2s and 0 in turn, checking the display shows
+// 01:30, 00:02 (rounded up) and 00:00.
+func TestSetDuration(t *testing.T) {
+	m := New()
+	m.PressDigit(7)
+
+	tests := []struct {
+		d    time.Duration
+		want string
+	}{
+		{90 * time.Second, "01:30"},
+		{1200 * time.Millisecond, "00:02"},
+		{0, "00:00"},
+	}
+	for _, tt := range tests {
+		m.SetDuration(tt.d)
+		if got := m.Display(); got != tt.want {
+			t.Errorf("SetDuration(%v): Display() = %s, want %s", tt.d, got, tt.want)
+		}
+	}
+}
+
+// TestSetDurationEdgeCases verifies how SetDuration handles negative, zero and huge durations.
+// Test logic: Starting from an entered 00:07, sets -5s, 0 and 10h and checks the resulting
+// display and the warnings logged for each: -5s is rejected and leaves 00:07, 0 clears the
+// display without a warning, and 10h is clamped to 99:99 with exactly one clamp warning.
+func TestSetDurationEdgeCases(t *testing.T) {
+	tests := []struct {
+		name        string
+		d           time.Duration
+		wantDisplay string
+		wantWarning string
+	}{
+		{"negative", -5 * time.Second, "00:07", "negative duration rejected"},
+		{"zero", 0, "00:00", ""},
+		{"ten hours", 10 * time.Hour, "99:99", "duration clamped to maximum"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			logger := slog.New(slog.NewJSONHandler(&buf, nil))
+			m := New(WithLogger(logger))
+			m.PressDigit(7)
+			buf.Reset()
+
+			m.SetDuration(tt.d)
+
+			if got := m.Display(); got != tt.wantDisplay {
+				t.Errorf("Display() = %s, want %s", got, tt.wantDisplay)
+			}
+			warnings := strings.Count(buf.String(), `"level":"WARN"`)
+			if tt.wantWarning == "" {
+				if warnings != 0 {
+					t.Errorf("expected no warnings, got:\n%s", buf.String())
+				}
+				return
+			}
+			if warnings != 1 || !strings.Contains(buf.String(), tt.wantWarning) {
+				t.Errorf("expected a single %q warning, got:\n%s", tt.wantWarning, buf.String())
+			}
+		})
+	}
+}
+
+// TestSetDurationDoesNotAutoStart verifies that SetDuration never triggers auto-start.
+// Test logic: With auto-start on, sets a 5 second duration and checks no countdown timer
+// was scheduled on the fake clock.
+func TestSetDurationDoesNotAutoStart(t *testing.T) {
+	clock := newFakeClock()
+	m := New(WithClock(clock), WithAutoStartOnFull(true))
+
+	m.SetDuration(5 * time.Second)
+
+	clock.mu.Lock()
+	pending := len(clock.waiters)
+	clock.mu.Unlock()
+	if pending != 0 || m.IsCooking() {
+		t.Error("SetDuration should not start cooking")
+	}
+}
+
+// PressStart Test Cases
+
+// TestPressStartIgnoresPressesWhileCooking verifies that PressStart is ignored while cooking.
+// Test logic: Sets up time, sets isCooking=true, calls PressStart, then verifies
+// "start ignored, already cooking" warning appears and cooking state is unchanged.
+func TestPressStartIgnoresPressesWhileCooking(t *testing.T) {
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewJSONHandler(&buf, nil))
+
+	m := New(WithLogger(logger))
+
+	// Make sure there is time on the clock
+	// so we avoid warning about starting with zero time.
+	m.PressDigit(4)
+	if got := m.Display(); got != "00:04" {
+		t.Errorf("Display() = %s, want 00:04", got)
+	}
+
+	// Set cooking to true
+	m.mu.Lock()
+	m.isCooking = true
+	m.mu.Unlock()
+
+	// press start
+	m.PressStart(context.Background())
+
+	logs := buf.String()
+	if !strings.Contains(logs, "start ignored, already cooking") {
+		t.Error("expected 'start ignored, already cooking' warning in logs")
+	}
+
+	if m.IsCooking() != true {
+		t.Error("should still be cooking after pressing start while cooking")
+	}
+}
+
+// TestPressStartWithZeroTime verifies that PressStart is ignored when no time is set.
+// Test logic: Calls PressStart without entering any digits, verifies "cannot start
+// with zero time" warning appears and cooking does not start.
+func TestPressStartWithZeroTime(t *testing.T) {
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewJSONHandler(&buf, nil))
+
+	m := New(WithLogger(logger))
+
+	// Don't enter any digits, try to start
+	m.PressStart(context.Background())
+
+	logs := buf.String()
+	if !strings.Contains(logs, "cannot start with zero time") {
+		t.Error("expected 'cannot start with zero time' warning")
+	}
+
+	if m.IsCooking() {
+		t.Error("should not be cooking with zero time")
+	}
+}
+
+// TestPressStartPartialEntryRejected verifies that RejectPartial refuses a single digit entry.
+// Test logic: With the RejectPartial policy, enters one digit and presses start, then checks
+// cooking did not start, the warning was logged, and the entered digit is kept.
+func TestPressStartPartialEntryRejected(t *testing.T) {
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewJSONHandler(&buf, nil))
+
+	m := New(WithLogger(logger), WithPartialEntryPolicy(RejectPartial))
+
+	// Enter a single digit and try to start
+	m.PressDigit(1)
+	m.PressStart(context.Background())
+
+	if m.IsCooking() {
+		t.Error("should not be cooking with a partial entry")
+	}
+	if !strings.Contains(buf.String(), "start rejected, enter all four digits") {
+		t.Error("expected 'start rejected, enter all four digits' warning in logs")
+	}
+	if got := m.Display(); got != "00:01" {
+		t.Errorf("Display() = %s, want 00:01", got)
+	}
+}
+
+// CancelCook Test Cases
+
+// TestCancelCookWhenIdle verifies that CancelCook returns false when nothing is cooking.
+// Test logic: Calls CancelCook on a new Microwave and checks it returns false.
+func TestCancelCookWhenIdle(t *testing.T) {
+	m := New()
+
+	if m.CancelCook() {
+		t.Error("CancelCook() = true, want false when idle")
+	}
+}
+
+// SkipToEnd Test Cases
+
+// TestSkipToEndWhenIdle verifies that SkipToEnd returns false when nothing is cooking.
+// Test logic: Calls SkipToEnd on a new Microwave and checks it returns false.
+func TestSkipToEndWhenIdle(t *testing.T) {
+	m := New()
+
+	if m.SkipToEnd() {
+		t.Error("SkipToEnd() = true, want false when idle")
+	}
+}
+
+// totalSeconds test cases
+
+// TestTotalSeconds verifies that totalSeconds correctly converts digits to seconds.
+// Test logic: Sets various digit combinations and verifies totalSeconds returns
+// the correct number of seconds: 00:00=0, 00:05=5, 01:30=90, 99:99=6039.
+func TestTotalSeconds(t *testing.T) {
+	m := New()
+
+	// 00:00 = 0 seconds
+	m.mu.Lock()
+	m.digits = [4]int{0, 0, 0, 0}
+	s := m.totalSeconds()
 	m.mu.Unlock()
 	if s != 0 {
 		t.Errorf("expected 0 seconds, got %d", s)
@@ -441,6 +1201,20 @@ func TestTotalSeconds(t *testing.T) {
 	}
 }
 
+// QueueTime Test Cases
+
+// TestQueueTimeRefused verifies that QueueTime refuses without the option or a running cook.
+// Test logic: Calls QueueTime on a microwave without WithCookQueue and on an idle one with
+// it, and checks both return false.
+func TestQueueTimeRefused(t *testing.T) {
+	if New().QueueTime(3) {
+		t.Error("QueueTime() = true without WithCookQueue, want false")
+	}
+	if New(WithCookQueue(true)).QueueTime(3) {
+		t.Error("QueueTime() = true while idle, want false")
+	}
+}
+
 // countdown Test Cases
 
 // TestCountdownCompletesSuccessfully verifies that countdown returns true when it completes normally.
@@ -450,7 +1224,7 @@ func TestCountdownCompletesSuccessfully(t *testing.T) {
 	m := New()
 
 	// Call countdown with 1 second
-	result := m.countdown(context.Background(), 1)
+	result := m.countdown(context.Background(), 1, nil)
 
 	// Should return true when completed normally
 	if !result {
@@ -470,7 +1244,7 @@ func TestCountdownWithZeroSeconds(t *testing.T) {
 	m := New()
 
 	// Call countdown with 0 seconds - should return immediately
-	result := m.countdown(context.Background(), 0)
+	result := m.countdown(context.Background(), 0, nil)
 
 	// Should return true (loop doesn't execute, but final display is printed)
 	if !result {
@@ -496,7 +1270,7 @@ func TestCountdownCancellation(t *testing.T) {
 	cancel()
 
 	// Call countdown - should return false due to cancellation
-	result := m.countdown(ctx, 10)
+	result := m.countdown(ctx, 10, nil)
 
 	if result {
 		t.Error("countdown() should return false when context is canceled")
@@ -515,7 +1289,7 @@ func TestCountdownUpdatesDisplay(t *testing.T) {
 	m.mu.Unlock()
 
 	// Run countdown for 1 second
-	m.countdown(context.Background(), 1)
+	m.countdown(context.Background(), 1, nil)
 
 	// After countdown, display should be 00:00
 	if got := m.Display(); got != "00:00" {
@@ -543,7 +1317,7 @@ func TestCountdownOverflowMinsEqualsOne(t *testing.T) {
 	// Start with 6000 seconds (100:00)
 	done := make(chan bool)
 	go func() {
-		m.countdown(ctx, 6000)
+		m.countdown(ctx, 6000, nil)
 		done <- true
 	}()
 
@@ -560,136 +1334,683 @@ func TestCountdownOverflowMinsEqualsOne(t *testing.T) {
 	<-done
 }
 
-// TestCountdownOverflowMinsGreaterThanOne verifies the overflowMins > 1 branch in countdown.
-// Test logic: Starts countdown with 12000 seconds (200 minutes), which triggers the extreme
-// overflow case where display is capped at 99:99 and a warning is logged.
-// Verifies display shows 99:99 and the warning message appears in logs.
-func TestCountdownOverflowMinsGreaterThanOne(t *testing.T) {
+// TestCountdownClampsAboveMaximum verifies that countdown clamps times above 99:99 once.
+// Test logic: Starts countdown with 12000 seconds (200 minutes) on a fake clock, runs two ticks,
+// then cancels. Verifies the display is capped at 99:99 and the clamp warning was logged once.
+func TestCountdownClampsAboveMaximum(t *testing.T) {
 	var buf bytes.Buffer
 	logger := slog.New(slog.NewJSONHandler(&buf, &slog.HandlerOptions{
 		Level: slog.LevelDebug,
 	}))
 
-	m := New(WithLogger(logger))
+	clock := newFakeClock()
+	m := New(WithLogger(logger), WithClock(clock))
 
 	ctx, cancel := context.WithCancel(context.Background())
 
-	// 200 minutes = 12000 seconds, which triggers overflowMins > 1
+	// 200 minutes = 12000 seconds, more than the display can show
 	done := make(chan bool)
 	go func() {
-		m.countdown(ctx, 12000)
+		m.countdown(ctx, 12000, nil)
 		done <- true
 	}()
 
-	// Wait briefly for first tick, then cancel and wait for goroutine
-	time.Sleep(100 * time.Millisecond)
-	cancel()
-	<-done
-
-	// Check display shows 99:99 (max overflow)
+	// Check the first frame is clamped to the maximum
+	clock.BlockUntil(t, 1)
 	if got := m.Display(); got != "99:99" {
 		t.Errorf("Display() = %s, want 99:99", got)
 	}
 
-	// Check warning was logged
+	// Run a couple of ticks, then cancel and wait for goroutine
+	clock.Tick(t, 2)
+	clock.BlockUntil(t, 1)
+	cancel()
+	<-done
+
+	// Check the clamp warning was logged once, not on every tick
 	logs := buf.String()
-	if !strings.Contains(logs, "unexpected overflowMins > 1") {
-		t.Error("expected 'unexpected overflowMins > 1' warning in logs")
+	if count := strings.Count(logs, "cooking time clamped to maximum"); count != 1 {
+		t.Errorf("expected 1 clamp warning in logs, got %d", count)
 	}
 }
 
-// Logging Test Cases
-
-// TestLogging verifies that PressDigit logs the digit pressed message.
-// Test logic: Creates a logger that writes to a buffer, presses digit 5,
-// then verifies "digit pressed" message and digit value appear in logs.
-func TestLogging(t *testing.T) {
-	var buf bytes.Buffer
+// TestCountdownClampsNegative verifies that a negative countdown completes at once with a warning.
+// Test logic: Calls countdown directly with -5 seconds, and checks it reports completion
+// without waiting on the clock, prints only the final 00:00 frame, leaves nothing remaining,
+// and logs the clamp warning.
+func TestCountdownClampsNegative(t *testing.T) {
+	var buf, out bytes.Buffer
 	logger := slog.New(slog.NewJSONHandler(&buf, nil))
+	m := New(WithLogger(logger), WithClock(newFakeClock()), WithOutput(&out))
 
-	m := New(WithLogger(logger))
-	m.PressDigit(5)
+	// The fake clock never advances, so any wait would hang the test
+	if !m.countdown(context.Background(), -5, nil) {
+		t.Fatal("countdown(-5) = false, want completed")
+	}
 
-	logs := buf.String()
-	if !strings.Contains(logs, "digit pressed") {
-		t.Error("expected 'digit pressed' in logs")
+	// Only the final frame is printed, and nothing is left to count down
+	if got := out.String(); got != "00:00\r\n" {
+		t.Errorf("output = %q, want only the final 00:00 frame", got)
 	}
-	if !strings.Contains(logs, `"digit":5`) {
-		t.Error("expected digit value 5 in logs")
+	if got := m.RemainingSeconds(); got != 0 {
+		t.Errorf("RemainingSeconds() = %d, want 0", got)
+	}
+
+	// Check the clamp was logged
+	if !strings.Contains(buf.String(), "negative cooking time clamped to zero") {
+		t.Errorf("expected clamp warning in logs, got %s", buf.String())
 	}
 }
 
-// TestLoggingDigitPressCount verifies that each digit press is logged separately.
-// Test logic: Presses digit 9 twice, then counts occurrences of "digit":9 in logs
-// to verify each press was logged individually.
-func TestLoggingDigitPressCount(t *testing.T) {
-	var buf bytes.Buffer
-	logger := slog.New(slog.NewJSONHandler(&buf, nil))
+// tickInterval Test Cases
 
-	m := New(WithLogger(logger))
+// TestTickIntervalNoJitterByDefault verifies that ticks last exactly one logical second by default.
+// Test logic: Creates a microwave with a 100ms logical second and no jitter and checks
+// tickInterval returns 100ms every time.
+func TestTickIntervalNoJitterByDefault(t *testing.T) {
+	m := New(WithLogicalSecond(100 * time.Millisecond))
 
-	// Press digit 9 twice
-	m.PressDigit(9)
-	m.PressDigit(9)
+	for range 10 {
+		if got := m.tickInterval(); got != 100*time.Millisecond {
+			t.Fatalf("tickInterval() = %v, want 100ms", got)
+		}
+	}
+}
 
-	logs := buf.String()
+// TestTickIntervalJitterSeedRepeatable verifies that the same seed gives the same jittered ticks.
+// Test logic: Creates two microwaves with 20% jitter and the same seed, draws 20 intervals
+// from each, and checks the sequences match and are not all the same length.
+func TestTickIntervalJitterSeedRepeatable(t *testing.T) {
+	a := New(WithRandomizedTickJitter(0.2), WithJitterSeed(7))
+	b := New(WithRandomizedTickJitter(0.2), WithJitterSeed(7))
+
+	varied := false
+	first := a.tickInterval()
+	if got := b.tickInterval(); got != first {
+		t.Fatalf("first intervals differ: %v vs %v", first, got)
+	}
+	for range 19 {
+		ia, ib := a.tickInterval(), b.tickInterval()
+		if ia != ib {
+			t.Fatalf("intervals differ with the same seed: %v vs %v", ia, ib)
+		}
+		if ia != first {
+			varied = true
+		}
+	}
 
-	// Verify digit 9 was pressed exactly 2 times
-	count := strings.Count(logs, `"digit":9`)
-	if count != 2 {
-		t.Errorf("expected 2 presses of digit 9, got %d", count)
+	// Jitter should actually vary the tick length
+	if !varied {
+		t.Error("expected jittered intervals to vary")
 	}
 }
 
-// Integration and Concurrency Test Cases
-// Run with the race detector to check for data races:
-//
-//	go test -race -run <test-name> ./internal/microwave
+// TestWithRandomizedTickJitterIgnoresInvalid verifies that out of range fractions leave ticks regular.
+// Test logic: Applies fractions of 0, -0.5 and 1 and checks no jitter RNG is set up.
+func TestWithRandomizedTickJitterIgnoresInvalid(t *testing.T) {
+	for _, fraction := range []float64{0, -0.5, 1} {
+		m := New(WithRandomizedTickJitter(fraction))
+		if m.rng != nil {
+			t.Errorf("WithRandomizedTickJitter(%v) should be ignored", fraction)
+		}
+	}
+}
 
-// TestIntegrationTracerCreatesSpan verifies that cooking creates an OpenTelemetry span.
-// Test logic: Sets up in-memory span exporter, starts cooking for 1 second, then
-// verifies a "cooking_session" span was created and exported.
-func TestIntegrationTracerCreatesSpan(t *testing.T) {
-	// Create an in-memory span exporter
-	exporter := tracetest.NewInMemoryExporter()
-	tp := trace.NewTracerProvider(trace.WithSyncer(exporter))
-	tracer := tp.Tracer("test")
+// limitAttributes Test Cases
 
-	m := New(WithTracer(tracer))
+// TestLimitAttributesCapsCardinality verifies that distinct values beyond the limit become "other".
+// Test logic: With a limit of 3, feeds 10 distinct user_id values followed by a repeat of the
+// first one, and checks only the first 3 values pass through, the rest are "other", the
+// repeat keeps its value, and a warning is logged for each capped value.
+func TestLimitAttributesCapsCardinality(t *testing.T) {
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewJSONHandler(&buf, nil))
+	m := New(WithLogger(logger), WithMetricAttributeLimit(3))
+
+	distinct := make(map[string]bool)
+	for i := range 10 {
+		got := m.limitAttributes([]attribute.KeyValue{attribute.String("user_id", fmt.Sprintf("user-%d", i))})
+		value := got[0].Value.AsString()
+		distinct[value] = true
+		if i >= 3 && value != "other" {
+			t.Errorf("value %d = %q, want other", i, value)
+		}
+	}
+	if len(distinct) != 4 {
+		t.Errorf("got %d distinct values, want 3 plus other", len(distinct))
+	}
 
-	// Enter 1 second and start cooking
-	m.PressDigit(1)
-	m.PressStart(context.Background())
+	// An already seen value keeps passing through
+	if got := m.limitAttributes([]attribute.KeyValue{attribute.String("user_id", "user-0")}); got[0].Value.AsString() != "user-0" {
+		t.Errorf("repeat value = %q, want user-0", got[0].Value.AsString())
+	}
 
-	// Verify a span was created
-	spans := exporter.GetSpans()
-	if len(spans) == 0 {
-		t.Error("expected at least one span to be created")
+	if n := strings.Count(buf.String(), "metric attribute limit reached"); n != 7 {
+		t.Errorf("expected 7 limit warnings, got %d", n)
 	}
+}
 
-	// Verify the cooking_session span exists
-	found := false
-	for _, span := range spans {
-		if span.Name == "cooking_session" {
-			found = true
-			break
+// TestLimitAttributesUnlimitedByDefault verifies that attributes pass through without a limit.
+// Test logic: Feeds 100 distinct values without the option and checks none are replaced.
+func TestLimitAttributesUnlimitedByDefault(t *testing.T) {
+	m := New()
+	for i := range 100 {
+		kv := attribute.Int("request", i)
+		if got := m.limitAttributes([]attribute.KeyValue{kv}); got[0] != kv {
+			t.Fatalf("limitAttributes(%v) = %v, want unchanged", kv, got[0])
 		}
 	}
-	if !found {
-		t.Error("expected 'cooking_session' span")
-	}
 }
 
-// TestIntegrationMeterRecordsMetrics verifies that button presses are recorded as metrics.
-// Test logic: Sets up manual metric reader, presses digits 1 and 2, collects metrics,
-// then verifies "microwave.button_presses" metric exists in the collected data.
-func TestIntegrationMeterRecordsMetrics(t *testing.T) {
-	// Create a manual reader to collect metrics
-	reader := sdkmetric.NewManualReader()
-	mp := sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))
-	meter := mp.Meter("test")
+// Rejection Handler Test Cases
 
-	m := New(WithMeter(meter))
+// rejection is a single call to a rejection handler
+type rejection struct {
+	reason RejectReason
+	detail string
+}
+
+// recordRejections returns an option that records rejection handler calls
+func recordRejections(got *[]rejection) Option {
+	return WithRejectionHandler(func(reason RejectReason, detail string) {
+		*got = append(*got, rejection{reason, detail})
+	})
+}
+
+// TestRejectionHandlerZeroTime verifies that starting with 00:00 reports ZeroTime.
+// Test logic: Presses start with nothing entered and checks the handler was called once
+// with ZeroTime and the logged message as the detail.
+func TestRejectionHandlerZeroTime(t *testing.T) {
+	var got []rejection
+	m := New(recordRejections(&got))
+
+	m.PressStart(context.Background())
+
+	if len(got) != 1 {
+		t.Fatalf("expected 1 rejection, got %d", len(got))
+	}
+	if got[0].reason != ZeroTime {
+		t.Errorf("reason = %v, want ZeroTime", got[0].reason)
+	}
+	if got[0].detail != "cannot start with zero time" {
+		t.Errorf("detail = %q, want %q", got[0].detail, "cannot start with zero time")
+	}
+}
+
+// TestRejectionHandlerDigitReasons verifies the reasons reported for refused digits.
+// Test logic: Presses an invalid digit, then five valid digits, and checks the handler
+// reported InvalidDigit and MaxDigits in order and was not called for accepted digits.
+func TestRejectionHandlerDigitReasons(t *testing.T) {
+	var got []rejection
+	m := New(recordRejections(&got))
+
+	m.PressDigit(-1)
+	for range 5 {
+		m.PressDigit(1)
+	}
+
+	if len(got) != 2 {
+		t.Fatalf("expected 2 rejections, got %d: %v", len(got), got)
+	}
+	if got[0].reason != InvalidDigit || got[1].reason != MaxDigits {
+		t.Errorf("reasons = %v, %v, want InvalidDigit, MaxDigits", got[0].reason, got[1].reason)
+	}
+}
+
+// TestRejectionHandlerPartialEntry verifies that RejectPartial refusals report PartialEntry.
+// Test logic: With the RejectPartial policy, enters one digit, presses start, and checks
+// the handler reported PartialEntry.
+func TestRejectionHandlerPartialEntry(t *testing.T) {
+	var got []rejection
+	m := New(WithPartialEntryPolicy(RejectPartial), recordRejections(&got))
+
+	m.PressDigit(5)
+	m.PressStart(context.Background())
+
+	if len(got) != 1 || got[0].reason != PartialEntry {
+		t.Errorf("rejections = %v, want one PartialEntry", got)
+	}
+}
+
+// TestPreStartValidator verifies that a WithPreStartValidator error blocks the start.
+// Test logic: With a validator rejecting more than 5 seconds, enters 00:10 and presses
+// start, checking nothing cooked and ValidatorRejected was reported with the validator's
+// message. Then sets 3 seconds and checks the validator saw 3 and the cook ran.
+func TestPreStartValidator(t *testing.T) {
+	var got []rejection
+	var validated []int
+	clock := newFakeClock()
+	m := New(
+		WithClock(clock),
+		WithOutput(io.Discard),
+		recordRejections(&got),
+		WithPreStartValidator(func(seconds int) error {
+			validated = append(validated, seconds)
+			if seconds > 5 {
+				return fmt.Errorf("%d seconds is over the 5 second limit", seconds)
+			}
+			return nil
+		}),
+	)
+
+	// 10 seconds is vetoed
+	m.PressDigit(1)
+	m.PressDigit(0)
+	m.PressStart(context.Background())
+
+	if _, ok := m.LastCook(); ok {
+		t.Error("a vetoed start should not cook")
+	}
+	want := []rejection{{ValidatorRejected, "10 seconds is over the 5 second limit"}}
+	if fmt.Sprint(got) != fmt.Sprint(want) {
+		t.Errorf("rejections = %v, want %v", got, want)
+	}
+
+	// 3 seconds is allowed
+	m.SetDuration(3 * time.Second)
+	done := make(chan struct{})
+	go func() {
+		m.PressStart(context.Background())
+		close(done)
+	}()
+	clock.Tick(t, 3)
+	<-done
+
+	if last, ok := m.LastCook(); !ok || !last.Completed {
+		t.Errorf("LastCook() = %+v, %v; want a completed cook", last, ok)
+	}
+	if fmt.Sprint(validated) != "[10 3]" {
+		t.Errorf("validator called with %v, want [10 3]", validated)
+	}
+}
+
+// TestRejectReasonString verifies that the reason values and their string forms stay stable.
+// Test logic: Uses table-driven tests to check each reason's numeric value and String form
+// against the released ones, and that an unknown value prints as RejectReason(n).
+func TestRejectReasonString(t *testing.T) {
+	tests := []struct {
+		reason RejectReason
+		value  int
+		want   string
+	}{
+		{ZeroTime, 0, "zero_time"},
+		{AlreadyCooking, 1, "already_cooking"},
+		{DigitWhileCooking, 2, "digit_while_cooking"},
+		{MaxDigits, 3, "max_digits"},
+		{InvalidDigit, 4, "invalid_digit"},
+		{PartialEntry, 5, "partial_entry"},
+		{DeadlinePassed, 6, "deadline_passed"},
+		{NegativeDuration, 7, "negative_duration"},
+		{BudgetExhausted, 8, "budget_exhausted"},
+		{ValidatorRejected, 9, "validator_rejected"},
+		{QueueFull, 10, "queue_full"},
+		{RateLimited, 11, "rate_limited"},
+		{TargetTooLow, 12, "target_too_low"},
+		{RejectReason(99), 99, "RejectReason(99)"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.want, func(t *testing.T) {
+			if int(tt.reason) != tt.value {
+				t.Errorf("%s = %d, want %d", tt.want, int(tt.reason), tt.value)
+			}
+			if got := tt.reason.String(); got != tt.want {
+				t.Errorf("RejectReason(%d).String() = %q, want %q", tt.value, got, tt.want)
+			}
+		})
+	}
+}
+
+// TestRejectionsMetric verifies that refusals are counted in the rejections metric by reason.
+// Test logic: Presses an invalid digit, start with nothing entered, then another invalid digit,
+// and checks the rejections counter holds 2 for invalid_digit and 1 for zero_time.
+func TestRejectionsMetric(t *testing.T) {
+	reader := sdkmetric.NewManualReader()
+	mp := sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))
+	m := New(WithMeter(mp.Meter("test")))
+
+	m.PressDigit(-1)
+	m.PressStart(context.Background())
+	m.PressDigit(10)
+
+	var rm metricdata.ResourceMetrics
+	if err := reader.Collect(context.Background(), &rm); err != nil {
+		t.Fatalf("failed to collect metrics: %v", err)
+	}
+	reasons := map[string]int64{}
+	for _, sm := range rm.ScopeMetrics {
+		for _, md := range sm.Metrics {
+			if md.Name != "microwave.rejections" {
+				continue
+			}
+			for _, dp := range md.Data.(metricdata.Sum[int64]).DataPoints {
+				reason, _ := dp.Attributes.Value("reason")
+				reasons[reason.AsString()] += dp.Value
+			}
+		}
+	}
+	if len(reasons) != 2 || reasons["invalid_digit"] != 2 || reasons["zero_time"] != 1 {
+		t.Errorf("rejections = %v, want invalid_digit:2 zero_time:1", reasons)
+	}
+}
+
+// Logging Test Cases
+
+// TestLogging verifies that PressDigit logs the digit pressed message.
+// Test logic: Creates a logger that writes to a buffer, presses digit 5,
+// then verifies "digit pressed" message and digit value appear in logs.
+func TestLogging(t *testing.T) {
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewJSONHandler(&buf, nil))
+
+	m := New(WithLogger(logger))
+	m.PressDigit(5)
+
+	logs := buf.String()
+	if !strings.Contains(logs, "digit pressed") {
+		t.Error("expected 'digit pressed' in logs")
+	}
+	if !strings.Contains(logs, `"digit":5`) {
+		t.Error("expected digit value 5 in logs")
+	}
+}
+
+// TestLoggingDigitPressCount verifies that each digit press is logged separately.
+// Test logic: Presses digit 9 twice, then counts occurrences of "digit":9 in logs
+// to verify each press was logged individually.
+func TestLoggingDigitPressCount(t *testing.T) {
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewJSONHandler(&buf, nil))
+
+	m := New(WithLogger(logger))
+
+	// Press digit 9 twice
+	m.PressDigit(9)
+	m.PressDigit(9)
+
+	logs := buf.String()
+
+	// Verify digit 9 was pressed exactly 2 times
+	count := strings.Count(logs, `"digit":9`)
+	if count != 2 {
+		t.Errorf("expected 2 presses of digit 9, got %d", count)
+	}
+}
+
+// TestTickLogLevel verifies that countdown ticks are logged at the configured level, apart from other debug logs.
+// Test logic: Uses table-driven tests to cook 00:02 on a fake clock with a debug-level JSON logger
+// and different tick levels, then checks every "tick" line has the expected level (or that there
+// are none when the level is below the handler's) while "display updated" is still logged at DEBUG.
+func TestTickLogLevel(t *testing.T) {
+	tests := []struct {
+		name      string
+		level     slog.Level
+		wantLevel string // "" when ticks should be silenced
+	}{
+		{"info", slog.LevelInfo, "INFO"},
+		{"silenced", slog.LevelDebug - 4, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			clock := newFakeClock()
+			m := New(
+				WithLogger(slog.New(slog.NewJSONHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug}))),
+				WithClock(clock),
+				WithOutput(io.Discard),
+				WithTickLogLevel(tt.level),
+			)
+
+			m.PressDigit(2)
+			done := make(chan struct{})
+			go func() {
+				m.PressStart(context.Background())
+				close(done)
+			}()
+			clock.Tick(t, 2)
+			<-done
+
+			ticks, displayUpdates := 0, 0
+			for _, line := range strings.Split(strings.TrimSpace(buf.String()), "\n") {
+				var entry struct {
+					Level string `json:"level"`
+					Msg   string `json:"msg"`
+				}
+				if err := json.Unmarshal([]byte(line), &entry); err != nil {
+					t.Fatalf("invalid log line %q: %v", line, err)
+				}
+				switch entry.Msg {
+				case "tick":
+					ticks++
+					if entry.Level != tt.wantLevel {
+						t.Errorf("tick logged at %s, want %s", entry.Level, tt.wantLevel)
+					}
+				case "display updated":
+					displayUpdates++
+					if entry.Level != "DEBUG" {
+						t.Errorf("display updated logged at %s, want DEBUG", entry.Level)
+					}
+				}
+			}
+
+			// Two countdown frames plus the final 00:00
+			wantTicks := 3
+			if tt.wantLevel == "" {
+				wantTicks = 0
+			}
+			if ticks != wantTicks {
+				t.Errorf("logged %d ticks, want %d", ticks, wantTicks)
+			}
+			if displayUpdates != 1 {
+				t.Errorf("logged %d display updates, want 1", displayUpdates)
+			}
+		})
+	}
+}
+
+// pressSeqs returns the seq attribute of each button press log line, in log order
+func pressSeqs(t *testing.T, logs string) []uint64 {
+	t.Helper()
+
+	var seqs []uint64
+	for _, line := range strings.Split(strings.TrimSpace(logs), "\n") {
+		var entry struct {
+			Msg string `json:"msg"`
+			Seq uint64 `json:"seq"`
+		}
+		if err := json.Unmarshal([]byte(line), &entry); err != nil {
+			t.Fatalf("invalid log line %q: %v", line, err)
+		}
+		if entry.Msg == "digit pressed" || entry.Msg == "start pressed" || entry.Msg == "invalid digit ignored" {
+			seqs = append(seqs, entry.Seq)
+		}
+	}
+	return seqs
+}
+
+// TestLoggingPressSequence verifies that button press logs carry strictly increasing sequence numbers.
+// Test logic: Presses start with zero time, three digits and an invalid digit, then reads
+// the seq attribute from each press log line and checks they run 1 through 5.
+func TestLoggingPressSequence(t *testing.T) {
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewJSONHandler(&buf, nil))
+	m := New(WithLogger(logger))
+
+	m.PressStart(context.Background())
+	m.PressDigit(1)
+	m.PressDigit(2)
+	m.PressDigit(3)
+	m.PressDigit(10)
+
+	seqs := pressSeqs(t, buf.String())
+	if len(seqs) != 5 {
+		t.Fatalf("expected 5 press log lines, got %d", len(seqs))
+	}
+	for i, seq := range seqs {
+		if want := uint64(i + 1); seq != want {
+			t.Errorf("press %d has seq %d, want %d", i+1, seq, want)
+		}
+	}
+}
+
+// TestLoggingIgnoredPressSequence verifies that lines for ignored presses carry the press's sequence number.
+// Test logic: Fills the display and presses a fifth digit, starts a cook on a fake clock,
+// then presses a digit, BACKSPACE and START while it runs, and checks each refusal line
+// has the seq of the press that caused it.
+func TestLoggingIgnoredPressSequence(t *testing.T) {
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewJSONHandler(&buf, nil))
+	clock := newFakeClock()
+	m := New(WithLogger(logger), WithClock(clock), WithOutput(io.Discard))
+
+	// Presses 1-4 fill the display, press 5 is refused
+	for _, d := range []int{0, 0, 1, 0, 5} {
+		m.PressDigit(d)
+	}
+
+	// Press 6 starts the cook
+	done := make(chan struct{})
+	go func() {
+		m.PressStart(context.Background())
+		close(done)
+	}()
+	clock.BlockUntil(t, 1)
+
+	// Presses 7-9 are refused while cooking
+	m.PressDigit(1)
+	m.PressBackspace()
+	m.PressStart(context.Background())
+	m.CancelCook()
+	<-done
+
+	want := map[string]uint64{
+		"max digits reached, display not updated": 5,
+		"digit ignored while cooking":             7,
+		"backspace ignored while cooking":         8,
+		"start ignored, already cooking":          9,
+	}
+	got := make(map[string]uint64)
+	for _, line := range strings.Split(strings.TrimSpace(buf.String()), "\n") {
+		var entry struct {
+			Msg string `json:"msg"`
+			Seq uint64 `json:"seq"`
+		}
+		if err := json.Unmarshal([]byte(line), &entry); err != nil {
+			t.Fatalf("invalid log line %q: %v", line, err)
+		}
+		if _, ok := want[entry.Msg]; ok {
+			got[entry.Msg] = entry.Seq
+		}
+	}
+	for msg, seq := range want {
+		if got[msg] != seq {
+			t.Errorf("%q has seq %d, want %d", msg, got[msg], seq)
+		}
+	}
+}
+
+// counterValue returns the sum of all data points of an Int64 counter, or 0 if not found
+func counterValue(rm metricdata.ResourceMetrics, name string) int64 {
+	var total int64
+	for _, sm := range rm.ScopeMetrics {
+		for _, m := range sm.Metrics {
+			if m.Name != name {
+				continue
+			}
+			if sum, ok := m.Data.(metricdata.Sum[int64]); ok {
+				for _, dp := range sum.DataPoints {
+					total += dp.Value
+				}
+			}
+		}
+	}
+	return total
+}
+
+// Integration and Concurrency Test Cases
+// Run with the race detector to check for data races:
+//
+//	go test -race -run <test-name> ./internal/microwave
+
+// TestIntegrationTracerCreatesSpan verifies that cooking creates an OpenTelemetry span.
+// Test logic: Sets up in-memory span exporter, starts cooking for 1 second, then
+// verifies a "cooking_session" span was created and exported.
+func TestIntegrationTracerCreatesSpan(t *testing.T) {
+	// Create an in-memory span exporter
+	exporter := tracetest.NewInMemoryExporter()
+	tp := trace.NewTracerProvider(trace.WithSyncer(exporter))
+	tracer := tp.Tracer("test")
+
+	m := New(WithTracer(tracer))
+
+	// Enter 1 second and start cooking
+	m.PressDigit(1)
+	m.PressStart(context.Background())
+
+	// Verify a span was created
+	spans := exporter.GetSpans()
+	if len(spans) == 0 {
+		t.Error("expected at least one span to be created")
+	}
+
+	// Verify the cooking_session span exists
+	found := false
+	for _, span := range spans {
+		if span.Name == "cooking_session" {
+			found = true
+			break
+		}
+	}
+	if !found {
+		t.Error("expected 'cooking_session' span")
+	}
+}
+
+// TestIntegrationInMemoryTracingRecordsSpans verifies that WithInMemoryTracing exposes spans via RecordedSpans.
+// Test logic: Checks RecordedSpans is nil without the option, then runs a 1 second cook on a
+// fake clock with in-memory tracing and checks a "cooking_session" span is retrievable.
+func TestIntegrationInMemoryTracingRecordsSpans(t *testing.T) {
+	// Without the option there is nothing to return
+	if spans := New().RecordedSpans(); spans != nil {
+		t.Errorf("RecordedSpans() = %v, want nil without in-memory tracing", spans)
+	}
+
+	clock := newFakeClock()
+	m := New(WithClock(clock), WithInMemoryTracing())
+
+	// Cook for 1 second
+	m.PressDigit(1)
+	done := make(chan struct{})
+	go func() {
+		m.PressStart(context.Background())
+		close(done)
+	}()
+	clock.Tick(t, 1)
+	<-done
+
+	found := false
+	for _, span := range m.RecordedSpans() {
+		if span.Name == "cooking_session" {
+			found = true
+			break
+		}
+	}
+	if !found {
+		t.Error("expected 'cooking_session' span in RecordedSpans()")
+	}
+}
+
+// TestIntegrationMeterRecordsMetrics verifies that button presses are recorded as metrics.
+// Test logic: Sets up manual metric reader, presses digits 1 and 2, collects metrics,
+// then verifies "microwave.button_presses" metric exists in the collected data.
+func TestIntegrationMeterRecordsMetrics(t *testing.T) {
+	// Create a manual reader to collect metrics
+	reader := sdkmetric.NewManualReader()
+	mp := sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))
+	meter := mp.Meter("test")
+
+	m := New(WithMeter(meter))
 
 	// Press some digits
 	m.PressDigit(1)
@@ -960,7 +2281,7 @@ func TestIntegrationCountdownConcurrent(t *testing.T) {
 	wg.Add(1)
 	go func() {
 		defer wg.Done()
-		m.countdown(ctx, 5)
+		m.countdown(ctx, 5, nil)
 	}()
 
 	// Spawn multiple readers that check Display and IsCooking
@@ -995,7 +2316,7 @@ func TestIntegrationCountdownCancellationMidway(t *testing.T) {
 	done := make(chan bool)
 	var result bool
 	go func() {
-		result = m.countdown(ctx, 10)
+		result = m.countdown(ctx, 10, nil)
 		done <- true
 	}()
 
@@ -1019,3 +2340,2407 @@ func TestIntegrationCountdownCancellationMidway(t *testing.T) {
 		t.Error("countdown() should return false when canceled midway")
 	}
 }
+
+// TestIntegrationElapsedSecondsDuringCook verifies that ElapsedSeconds tracks a running cook.
+// Test logic: Starts a 5 second cook driven by a fake clock, advances the clock one tick
+// at a time checking ElapsedSeconds increases by one each tick, then finishes the cook
+// and checks ElapsedSeconds returns to 0.
+func TestIntegrationElapsedSecondsDuringCook(t *testing.T) {
+	clock := newFakeClock()
+	m := New(WithClock(clock))
+
+	// Enter 5 seconds
+	m.PressDigit(5)
+
+	// Start cooking in a goroutine
+	done := make(chan bool)
+	go func() {
+		m.PressStart(context.Background())
+		done <- true
+	}()
+
+	// Advance one tick at a time and check elapsed time
+	for tick := 1; tick <= 3; tick++ {
+		clock.BlockUntil(t, 1)
+		clock.Advance(1 * time.Second)
+
+		if got := m.ElapsedSeconds(); got != tick {
+			t.Errorf("ElapsedSeconds() after %d ticks = %d, want %d", tick, got, tick)
+		}
+	}
+
+	// Let the remaining ticks run to completion
+	for range 2 {
+		clock.BlockUntil(t, 1)
+		clock.Advance(1 * time.Second)
+	}
+	<-done
+
+	// Elapsed time resets once cooking ends
+	if got := m.ElapsedSeconds(); got != 0 {
+		t.Errorf("ElapsedSeconds() after cooking = %d, want 0", got)
+	}
+}
+
+// TestIntegrationOnCompleteCompleted verifies that the completion callback reports a finished cook.
+// Test logic: Starts a 2 second cook driven by a fake clock, runs it to completion, and
+// checks the callback fired once with Completed=true, a session ID, and matching times.
+func TestIntegrationOnCompleteCompleted(t *testing.T) {
+	clock := newFakeClock()
+	results := make(chan CookResult, 2)
+	m := New(
+		WithClock(clock),
+		WithOnComplete(func(r CookResult) { results <- r }),
+	)
+
+	// Enter 2 seconds and start cooking
+	m.PressDigit(2)
+	done := make(chan bool)
+	go func() {
+		m.PressStart(context.Background())
+		done <- true
+	}()
+
+	// Run the countdown to completion
+	clock.Tick(t, 2)
+	<-done
+
+	// Verify the callback fired exactly once with the expected fields
+	if len(results) != 1 {
+		t.Fatalf("onComplete called %d times, want 1", len(results))
+	}
+	r := <-results
+	if !r.Completed {
+		t.Error("Completed = false, want true")
+	}
+	if r.SessionID == "" {
+		t.Error("expected a non-empty SessionID")
+	}
+	if r.RequestedSeconds != 2 {
+		t.Errorf("RequestedSeconds = %d, want 2", r.RequestedSeconds)
+	}
+	if r.ElapsedSeconds != 2 {
+		t.Errorf("ElapsedSeconds = %d, want 2", r.ElapsedSeconds)
+	}
+}
+
+// TestIntegrationOnCompleteCanceled verifies that the completion callback reports a canceled cook.
+// Test logic: Starts a 10 second cook driven by a fake clock, advances 3 ticks, cancels the
+// context, and checks the callback fired with Completed=false and 3 elapsed seconds.
+func TestIntegrationOnCompleteCanceled(t *testing.T) {
+	clock := newFakeClock()
+	results := make(chan CookResult, 2)
+	m := New(
+		WithClock(clock),
+		WithOnComplete(func(r CookResult) { results <- r }),
+	)
+
+	// Enter 10 seconds and start cooking
+	m.PressDigit(1)
+	m.PressDigit(0)
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan bool)
+	go func() {
+		m.PressStart(ctx)
+		done <- true
+	}()
+
+	// Run 3 ticks, then cancel
+	clock.Tick(t, 3)
+	clock.BlockUntil(t, 1)
+	cancel()
+	<-done
+
+	// Verify the callback fired exactly once with the expected fields
+	if len(results) != 1 {
+		t.Fatalf("onComplete called %d times, want 1", len(results))
+	}
+	r := <-results
+	if r.Completed {
+		t.Error("Completed = true, want false")
+	}
+	if r.RequestedSeconds != 10 {
+		t.Errorf("RequestedSeconds = %d, want 10", r.RequestedSeconds)
+	}
+	if r.ElapsedSeconds != 3 {
+		t.Errorf("ElapsedSeconds = %d, want 3", r.ElapsedSeconds)
+	}
+}
+
+// TestIntegrationLogicalSecondSpeedsUpCook verifies that a short logical second speeds up cooking.
+// Test logic: Runs a 3 second cook with a 100ms logical second on the real clock and checks
+// it completes in roughly 300ms, well under the 3 seconds a normal cook takes.
+func TestIntegrationLogicalSecondSpeedsUpCook(t *testing.T) {
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewJSONHandler(&buf, nil))
+
+	m := New(WithLogger(logger), WithLogicalSecond(100*time.Millisecond))
+
+	// Enter 3 seconds and time the cook
+	m.PressDigit(3)
+	begin := time.Now()
+	m.PressStart(context.Background())
+	took := time.Since(begin)
+
+	// Three 100ms ticks, with headroom for slow CI machines
+	if took < 300*time.Millisecond || took > 1500*time.Millisecond {
+		t.Errorf("cook took %v, want about 300ms", took)
+	}
+
+	// The cook should complete normally
+	if !strings.Contains(buf.String(), "cooking complete") {
+		t.Error("expected 'cooking complete' in logs")
+	}
+}
+
+// TestIntegrationCountdownClampRecordsMetric verifies that clamping a cooking time records a metric.
+// Test logic: Sets up a manual metric reader, runs a canceled countdown above the maximum,
+// collects metrics, and checks "microwave.clamped_durations" has a value of 1.
+func TestIntegrationCountdownClampRecordsMetric(t *testing.T) {
+	reader := sdkmetric.NewManualReader()
+	mp := sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))
+
+	m := New(WithMeter(mp.Meter("test")), WithClock(newFakeClock()))
+
+	// Countdown above the maximum with an already canceled context
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	m.countdown(ctx, maxSeconds+1, nil)
+
+	// Collect metrics
+	var rm metricdata.ResourceMetrics
+	if err := reader.Collect(context.Background(), &rm); err != nil {
+		t.Fatalf("failed to collect metrics: %v", err)
+	}
+
+	// Verify the clamp counter was incremented once
+	if got := counterValue(rm, "microwave.clamped_durations"); got != 1 {
+		t.Errorf("microwave.clamped_durations = %d, want 1", got)
+	}
+}
+
+// TestIntegrationPartialEntryPolicies verifies how each policy starts a single digit entry.
+// Test logic: For AsEntered and AssumeMinutes, enters the digit 1, starts cooking on a fake
+// clock, and checks the first countdown frame shows the expected time before canceling.
+// RejectPartial is covered by TestPressStartPartialEntryRejected since it never cooks.
+func TestIntegrationPartialEntryPolicies(t *testing.T) {
+	tests := []struct {
+		name     string
+		policy   PartialEntryPolicy
+		expected string
+	}{
+		{"AsEntered cooks seconds", AsEntered, "00:01"},
+		{"AssumeMinutes cooks minutes", AssumeMinutes, "01:00"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clock := newFakeClock()
+			m := New(WithClock(clock), WithPartialEntryPolicy(tt.policy))
+
+			// Enter a single digit and start cooking
+			m.PressDigit(1)
+			ctx, cancel := context.WithCancel(context.Background())
+			done := make(chan bool)
+			go func() {
+				m.PressStart(ctx)
+				done <- true
+			}()
+
+			// Check the first countdown frame
+			clock.BlockUntil(t, 1)
+			if got := m.Display(); got != tt.expected {
+				t.Errorf("Display() = %s, want %s", got, tt.expected)
+			}
+
+			cancel()
+			<-done
+		})
+	}
+}
+
+// TestIntegrationAssumeMinutesFourDigitsAsEntered verifies that AssumeMinutes leaves full entries alone.
+// Test logic: With AssumeMinutes, enters 0,0,4,5 and starts cooking on a fake clock, then checks
+// the first frame shows 00:45 rather than treating the digits as minutes.
+func TestIntegrationAssumeMinutesFourDigitsAsEntered(t *testing.T) {
+	clock := newFakeClock()
+	m := New(WithClock(clock), WithPartialEntryPolicy(AssumeMinutes))
+
+	for _, d := range []int{0, 0, 4, 5} {
+		m.PressDigit(d)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan bool)
+	go func() {
+		m.PressStart(ctx)
+		done <- true
+	}()
+
+	clock.BlockUntil(t, 1)
+	if got := m.Display(); got != "00:45" {
+		t.Errorf("Display() = %s, want 00:45", got)
+	}
+
+	cancel()
+	<-done
+}
+
+// TestIntegrationRemainingSecondsDuringCook verifies that RemainingSeconds counts down each tick.
+// Test logic: Starts a 5 second cook on a fake clock, checks RemainingSeconds after each tick,
+// then checks it returns to 0 once the cook is canceled.
+func TestIntegrationRemainingSecondsDuringCook(t *testing.T) {
+	clock := newFakeClock()
+	m := New(WithClock(clock))
+
+	// Enter 5 seconds and start cooking
+	m.PressDigit(5)
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan bool)
+	go func() {
+		m.PressStart(ctx)
+		done <- true
+	}()
+
+	// Each tick removes one second
+	for want := 5; want >= 3; want-- {
+		clock.BlockUntil(t, 1)
+		if got := m.RemainingSeconds(); got != want {
+			t.Errorf("RemainingSeconds() = %d, want %d", got, want)
+		}
+		clock.Advance(1 * time.Second)
+	}
+
+	// Cancel and check it resets
+	clock.BlockUntil(t, 1)
+	cancel()
+	<-done
+	if got := m.RemainingSeconds(); got != 0 {
+		t.Errorf("RemainingSeconds() after cancel = %d, want 0", got)
+	}
+}
+
+// TestIntegrationRemainingSecondsConcurrent verifies that RemainingSeconds() is safe to poll during a cook.
+// Test logic: Runs a countdown on a fake clock while 100 reader goroutines call RemainingSeconds
+// and Display, checking values stay in range. Must pass with race detector enabled.
+func TestIntegrationRemainingSecondsConcurrent(t *testing.T) {
+	clock := newFakeClock()
+	m := New(WithClock(clock))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan bool)
+	go func() {
+		m.countdown(ctx, 5, nil)
+		done <- true
+	}()
+
+	// Spawn readers while the countdown ticks
+	var wg sync.WaitGroup
+	for range 100 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if got := m.RemainingSeconds(); got < 0 || got > 5 {
+				t.Errorf("RemainingSeconds() = %d, want 0-5", got)
+			}
+			_ = m.Display()
+		}()
+	}
+	clock.Tick(t, 2)
+
+	wg.Wait()
+	clock.BlockUntil(t, 1)
+	cancel()
+	<-done
+}
+
+// TestIntegrationIDGeneratorSetsSessionID verifies that the session ID comes from the injected generator.
+// Test logic: Injects a generator returning sequential IDs, runs two 1 second cooks on a fake
+// clock with an in-memory span exporter, and checks each span's session_id attribute.
+func TestIntegrationIDGeneratorSetsSessionID(t *testing.T) {
+	exporter := tracetest.NewInMemoryExporter()
+	tp := trace.NewTracerProvider(trace.WithSyncer(exporter))
+
+	next := 0
+	clock := newFakeClock()
+	m := New(
+		WithTracer(tp.Tracer("test")),
+		WithClock(clock),
+		WithIDGenerator(func() string {
+			next++
+			return fmt.Sprintf("session-%d", next)
+		}),
+	)
+
+	// Run two 1 second cooks
+	for range 2 {
+		m.PressDigit(1)
+		done := make(chan bool)
+		go func() {
+			m.PressStart(context.Background())
+			done <- true
+		}()
+		clock.Tick(t, 1)
+		<-done
+	}
+
+	// Verify each span carries the generated ID
+	spans := exporter.GetSpans()
+	if len(spans) != 2 {
+		t.Fatalf("expected 2 spans, got %d", len(spans))
+	}
+	for i, span := range spans {
+		want := fmt.Sprintf("session-%d", i+1)
+		found := false
+		for _, attr := range span.Attributes {
+			if attr.Key == "session_id" && attr.Value.AsString() == want {
+				found = true
+			}
+		}
+		if !found {
+			t.Errorf("span %d missing session_id %q: %v", i, want, span.Attributes)
+		}
+	}
+}
+
+// TestIntegrationCancelCook verifies that CancelCook stops a running cook exactly once.
+// Test logic: Starts a 10 second cook on a fake clock, calls CancelCook and checks it returns
+// true and the cook ends as canceled, then checks a second CancelCook returns false.
+func TestIntegrationCancelCook(t *testing.T) {
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewJSONHandler(&buf, nil))
+
+	clock := newFakeClock()
+	m := New(WithLogger(logger), WithClock(clock))
+
+	// Enter 10 seconds and start cooking
+	m.PressDigit(1)
+	m.PressDigit(0)
+	done := make(chan bool)
+	go func() {
+		m.PressStart(context.Background())
+		done <- true
+	}()
+
+	// Wait for the first tick so the cook is running
+	clock.BlockUntil(t, 1)
+
+	// First cancel stops the cook
+	if !m.CancelCook() {
+		t.Error("CancelCook() = false, want true while cooking")
+	}
+	<-done
+
+	if m.IsCooking() {
+		t.Error("should not be cooking after CancelCook")
+	}
+	if !strings.Contains(buf.String(), "cooking canceled") {
+		t.Error("expected 'cooking canceled' in logs")
+	}
+
+	// Second cancel has nothing to do
+	if m.CancelCook() {
+		t.Error("second CancelCook() = true, want false")
+	}
+}
+
+// TestIntegrationCancelCookConcurrent verifies that concurrent CancelCook calls cancel only once.
+// Test logic: Starts a cook on a fake clock, calls CancelCook from 50 goroutines at once,
+// and checks exactly one call reports a cancellation. Must pass with race detector enabled.
+func TestIntegrationCancelCookConcurrent(t *testing.T) {
+	clock := newFakeClock()
+	m := New(WithClock(clock))
+
+	m.PressDigit(9)
+	done := make(chan bool)
+	go func() {
+		m.PressStart(context.Background())
+		done <- true
+	}()
+	clock.BlockUntil(t, 1)
+
+	// Cancel from many goroutines at once
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	canceled := 0
+	for range 50 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if m.CancelCook() {
+				mu.Lock()
+				canceled++
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+	<-done
+
+	if canceled != 1 {
+		t.Errorf("%d CancelCook calls returned true, want 1", canceled)
+	}
+}
+
+// TestIntegrationAutoStartOnFull verifies that entering a fourth digit starts cooking automatically.
+// Test logic: With auto-start on and a fake clock, enters 0,0,0,5 without pressing start,
+// waits for the countdown's first tick, checks cooking began with 00:05 showing, then
+// cancels the cook and waits for the completion callback. The start is logged as a
+// START press.
+func TestIntegrationAutoStartOnFull(t *testing.T) {
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewJSONHandler(&buf, nil))
+
+	clock := newFakeClock()
+	results := make(chan CookResult, 1)
+	m := New(
+		WithLogger(logger),
+		WithClock(clock),
+		WithAutoStartOnFull(true),
+		WithOnComplete(func(r CookResult) { results <- r }),
+	)
+
+	// Enter four digits, no start press
+	for _, d := range []int{0, 0, 0, 5} {
+		m.PressDigit(d)
+	}
+
+	// The countdown's first tick shows cooking has begun
+	clock.BlockUntil(t, 1)
+	if !m.IsCooking() {
+		t.Error("expected cooking to start automatically after the fourth digit")
+	}
+	if got := m.Display(); got != "00:05" {
+		t.Errorf("Display() = %s, want 00:05", got)
+	}
+
+	// Stop the cook and wait for it to end
+	m.CancelCook()
+	<-results
+
+	// Auto-start presses START for the user
+	logs := buf.String()
+	for _, want := range []string{"display full, starting automatically", "start pressed"} {
+		if !strings.Contains(logs, want) {
+			t.Errorf("expected %q in logs", want)
+		}
+	}
+}
+
+// TestIntegrationConcurrentStartsCookOnce verifies that two starts racing each other run only one cook.
+// Test logic: Holds the first start inside a pre-start validator, which runs after the start
+// has passed its cooking check, then presses START again. The second start must be refused
+// with AlreadyCooking instead of beginning a second cook, while IsCooking stays false since
+// nothing can be canceled yet. Releasing the first lets it cook.
+func TestIntegrationConcurrentStartsCookOnce(t *testing.T) {
+	var calls atomic.Int32
+	entered, release := make(chan struct{}), make(chan struct{})
+	rejections := make(chan RejectReason, 1)
+	clock := newFakeClock()
+	m := New(
+		WithClock(clock),
+		WithOutput(io.Discard),
+		WithPreStartValidator(func(int) error {
+			// Hold only the first start
+			if calls.Add(1) == 1 {
+				close(entered)
+				<-release
+			}
+			return nil
+		}),
+		WithRejectionHandler(func(reason RejectReason, _ string) { rejections <- reason }),
+	)
+	m.SetDuration(5 * time.Second)
+
+	first := make(chan struct{})
+	go func() {
+		m.PressStart(context.Background())
+		close(first)
+	}()
+	<-entered
+
+	// The second start is refused rather than cooking alongside the first
+	second := make(chan struct{})
+	go func() {
+		m.PressStart(context.Background())
+		close(second)
+	}()
+	select {
+	case <-second:
+	case <-time.After(2 * time.Second):
+		t.Fatal("second start did not return, want it refused")
+	}
+	if reason := <-rejections; reason != AlreadyCooking {
+		t.Errorf("second start rejected with %v, want AlreadyCooking", reason)
+	}
+	if n := calls.Load(); n != 1 {
+		t.Errorf("validator called %d times, want 1", n)
+	}
+	if m.IsCooking() {
+		t.Error("IsCooking() = true before the claimed cook began")
+	}
+
+	// The first start goes on to cook
+	close(release)
+	clock.BlockUntil(t, 1)
+	if got := m.Display(); got != "00:05" {
+		t.Errorf("Display() = %s, want 00:05", got)
+	}
+	m.CancelCook()
+	<-first
+}
+
+// TestIntegrationAutoStartOffByDefault verifies that four digits do not start cooking by default.
+// Test logic: Enters four digits on a fake clock without auto-start and checks no
+// countdown timer was scheduled and the microwave is not cooking.
+func TestIntegrationAutoStartOffByDefault(t *testing.T) {
+	clock := newFakeClock()
+	m := New(WithClock(clock))
+
+	for _, d := range []int{0, 0, 0, 5} {
+		m.PressDigit(d)
+	}
+
+	if m.IsCooking() {
+		t.Error("should not be cooking without auto-start")
+	}
+	clock.mu.Lock()
+	pending := len(clock.waiters)
+	clock.mu.Unlock()
+	if pending != 0 {
+		t.Errorf("expected no countdown timers, got %d", pending)
+	}
+}
+
+// TestIntegrationTickJitterWithinBounds verifies that jittered ticks stay within the configured fraction.
+// Test logic: Cooks 00:05 with 25% jitter and a fixed seed on a fake clock, advancing
+// past the longest possible tick each time, then checks every requested tick
+// duration was between 0.75s and 1.25s and that they were not all equal.
+func TestIntegrationTickJitterWithinBounds(t *testing.T) {
+	clock := newFakeClock()
+	m := New(WithClock(clock), WithRandomizedTickJitter(0.25), WithJitterSeed(42))
+	m.digits = [4]int{0, 0, 0, 5}
+	m.digitCount = 4
+
+	done := make(chan struct{})
+	go func() {
+		m.PressStart(context.Background())
+		close(done)
+	}()
+
+	// Advance far enough to fire any jittered tick
+	for range 5 {
+		clock.BlockUntil(t, 1)
+		clock.Advance(2 * time.Second)
+	}
+	<-done
+
+	clock.mu.Lock()
+	durations := append([]time.Duration(nil), clock.durations...)
+	clock.mu.Unlock()
+
+	if len(durations) != 5 {
+		t.Fatalf("expected 5 ticks, got %d", len(durations))
+	}
+	varied := false
+	for _, d := range durations {
+		if d < 750*time.Millisecond || d > 1250*time.Millisecond {
+			t.Errorf("tick of %v is outside 0.75s..1.25s", d)
+		}
+		if d != durations[0] {
+			varied = true
+		}
+	}
+	if !varied {
+		t.Error("expected jittered ticks to vary")
+	}
+}
+
+// TestIntegrationStartUntilCooksToDeadline verifies that StartUntil cooks until the clock reaches the deadline.
+// Test logic: On a fake clock, starts a cook with a deadline 3 seconds away, checks the
+// display shows 00:03, ticks 3 seconds, then checks the cook completed after 3 seconds
+// and ended exactly at the deadline.
+func TestIntegrationStartUntilCooksToDeadline(t *testing.T) {
+	clock := newFakeClock()
+	results := make(chan CookResult, 1)
+	m := New(WithClock(clock), WithOnComplete(func(r CookResult) { results <- r }))
+
+	deadline := clock.Now().Add(3 * time.Second)
+	done := make(chan struct{})
+	go func() {
+		m.StartUntil(context.Background(), deadline)
+		close(done)
+	}()
+
+	// The display shows the time until the deadline
+	clock.BlockUntil(t, 1)
+	if got := m.Display(); got != "00:03" {
+		t.Errorf("Display() = %s, want 00:03", got)
+	}
+
+	clock.Tick(t, 3)
+	<-done
+
+	r := <-results
+	if !r.Completed || r.RequestedSeconds != 3 || r.ElapsedSeconds != 3 {
+		t.Errorf("CookResult = %+v, want completed 3 second cook", r)
+	}
+	if !clock.Now().Equal(deadline) {
+		t.Errorf("cook ended at %v, want %v", clock.Now(), deadline)
+	}
+}
+
+// TestIntegrationStartUntilRoundsUp verifies that a deadline between seconds rounds the cook up.
+// Test logic: Starts a cook with a deadline 1.5 seconds away and checks the display shows
+// 00:02 so the cook does not end before the deadline, then cancels it.
+func TestIntegrationStartUntilRoundsUp(t *testing.T) {
+	clock := newFakeClock()
+	m := New(WithClock(clock))
+
+	done := make(chan struct{})
+	go func() {
+		m.StartUntil(context.Background(), clock.Now().Add(1500*time.Millisecond))
+		close(done)
+	}()
+
+	clock.BlockUntil(t, 1)
+	if got := m.Display(); got != "00:02" {
+		t.Errorf("Display() = %s, want 00:02", got)
+	}
+
+	m.CancelCook()
+	<-done
+}
+
+// TestIntegrationStartUntilRejectsPast verifies that a deadline that has passed does not start a cook.
+// Test logic: Calls StartUntil with the current time and a time in the past and checks each
+// returns without cooking and logs the rejection.
+func TestIntegrationStartUntilRejectsPast(t *testing.T) {
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewJSONHandler(&buf, nil))
+	clock := newFakeClock()
+	m := New(WithLogger(logger), WithClock(clock))
+
+	for _, deadline := range []time.Time{clock.Now(), clock.Now().Add(-time.Minute)} {
+		// Returns immediately instead of blocking on a countdown
+		m.StartUntil(context.Background(), deadline)
+		if m.IsCooking() {
+			t.Errorf("StartUntil(%v) should not cook", deadline)
+		}
+	}
+
+	if got := strings.Count(buf.String(), "start rejected, deadline has passed"); got != 2 {
+		t.Errorf("expected 2 rejection logs, got %d", got)
+	}
+}
+
+// TestIntegrationStartUntilClampsToMaximum verifies that a far deadline is clamped to 99:99.
+// Test logic: Starts a cook with a deadline 3 hours away, checks the countdown begins at
+// 99:99, then cancels it.
+func TestIntegrationStartUntilClampsToMaximum(t *testing.T) {
+	clock := newFakeClock()
+	m := New(WithClock(clock))
+
+	done := make(chan struct{})
+	go func() {
+		m.StartUntil(context.Background(), clock.Now().Add(3*time.Hour))
+		close(done)
+	}()
+
+	clock.BlockUntil(t, 1)
+	if got := m.Display(); got != "99:99" {
+		t.Errorf("Display() = %s, want 99:99", got)
+	}
+
+	m.CancelCook()
+	<-done
+}
+
+// TestIntegrationPressSequenceConcurrent verifies that concurrent presses get unique sequence numbers.
+// Test logic: Spawns 50 goroutines that each press a digit, then checks the seq values in
+// the logs are exactly 1 through 50 with no duplicates.
+func TestIntegrationPressSequenceConcurrent(t *testing.T) {
+	// The JSON handler serializes writes, so a plain buffer is safe here
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewJSONHandler(&buf, nil))
+	m := New(WithLogger(logger))
+
+	var wg sync.WaitGroup
+	for i := range 50 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			m.PressDigit(i % 10)
+		}()
+	}
+	wg.Wait()
+
+	seen := make(map[uint64]bool)
+	for _, seq := range pressSeqs(t, buf.String()) {
+		if seq < 1 || seq > 50 || seen[seq] {
+			t.Errorf("unexpected or duplicate seq %d", seq)
+		}
+		seen[seq] = true
+	}
+	if len(seen) != 50 {
+		t.Errorf("expected 50 unique seqs, got %d", len(seen))
+	}
+}
+
+// TestIntegrationRejectionHandlerWhileCooking verifies the reasons reported for presses during a cook.
+// Test logic: Starts a cook on a fake clock, presses a digit and start while it runs, then
+// checks the handler reported DigitWhileCooking and AlreadyCooking and can safely read the
+// microwave's state, which shows it is called without the lock held.
+func TestIntegrationRejectionHandlerWhileCooking(t *testing.T) {
+	clock := newFakeClock()
+	var m *Microwave
+	var got []rejection
+	m = New(WithClock(clock), WithRejectionHandler(func(reason RejectReason, detail string) {
+		// Reading state would deadlock if the lock were held
+		_ = m.Display()
+		got = append(got, rejection{reason, detail})
+	}))
+
+	m.PressDigit(5)
+	done := make(chan struct{})
+	go func() {
+		m.PressStart(context.Background())
+		close(done)
+	}()
+	clock.BlockUntil(t, 1)
+
+	m.PressDigit(1)
+	m.PressStart(context.Background())
+	m.CancelCook()
+	<-done
+
+	if len(got) != 2 || got[0].reason != DigitWhileCooking || got[1].reason != AlreadyCooking {
+		t.Errorf("rejections = %v, want DigitWhileCooking, AlreadyCooking", got)
+	}
+}
+
+// TestIntegrationDisplaySnapshotConcurrent verifies that lock-free Display reads stay valid during a cook.
+// Test logic: Starts a 00:05 cook on a fake clock and, while the countdown ticks, polls
+// Display from 4 goroutines. Every read must parse as MM:SS, or :SS with WithCompactSubMinute,
+// and never exceed 00:05, and once the cook ends Display must show zero. Runs with the
+// compact format both off and on.
+func TestIntegrationDisplaySnapshotConcurrent(t *testing.T) {
+	for _, compact := range []bool{false, true} {
+		t.Run(fmt.Sprintf("compact=%v", compact), func(t *testing.T) {
+			testDisplaySnapshotConcurrent(t, compact)
+		})
+	}
+}
+
+// testDisplaySnapshotConcurrent runs TestIntegrationDisplaySnapshotConcurrent
+// with the compact sub-minute format on or off
+func testDisplaySnapshotConcurrent(t *testing.T, compact bool) {
+	clock := newFakeClock()
+	m := New(WithClock(clock), WithCompactSubMinute(compact), WithOutput(io.Discard))
+	for _, d := range []int{0, 0, 0, 5} {
+		m.PressDigit(d)
+	}
+
+	done := make(chan struct{})
+	go func() {
+		m.PressStart(context.Background())
+		close(done)
+	}()
+
+	// Poll the display until the cook ends
+	var wg sync.WaitGroup
+	errs := make(chan string, 4)
+	for range 4 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				select {
+				case <-done:
+					return
+				default:
+				}
+				display := m.Display()
+				if compact && strings.HasPrefix(display, ":") {
+					display = "00" + display
+				}
+				seconds, err := ParseDisplay(display)
+				if err != nil || seconds > 5 {
+					errs <- display
+					return
+				}
+				// Let the countdown run between reads on small machines
+				runtime.Gosched()
+			}
+		}()
+	}
+
+	clock.Tick(t, 5)
+	<-done
+	wg.Wait()
+	close(errs)
+
+	for display := range errs {
+		t.Errorf("invalid display read during cook: %q", display)
+	}
+	want := "00:00"
+	if compact {
+		want = ":00"
+	}
+	if got := m.Display(); got != want {
+		t.Errorf("Display() = %s after cook, want %s", got, want)
+	}
+}
+
+// TestIntegrationDisplayThrottle verifies that WithDisplayThrottle drops frames but keeps the final one.
+// Test logic: Cooks 00:09 with a 100ms logical second and a 300ms throttle on a fake clock,
+// advancing 100ms per tick, then checks only the frames 300ms apart were printed
+// (00:09, 00:06, 00:03) followed by the final 00:00.
+func TestIntegrationDisplayThrottle(t *testing.T) {
+	var out bytes.Buffer
+	clock := newFakeClock()
+	m := New(
+		WithClock(clock),
+		WithOutput(&out),
+		WithLogicalSecond(100*time.Millisecond),
+		WithDisplayThrottle(300*time.Millisecond),
+	)
+	m.SetDuration(9 * time.Second)
+	out.Reset()
+
+	done := make(chan struct{})
+	go func() {
+		m.PressStart(context.Background())
+		close(done)
+	}()
+	for range 9 {
+		clock.BlockUntil(t, 1)
+		clock.Advance(100 * time.Millisecond)
+	}
+	<-done
+
+	frames := strings.Split(strings.TrimSuffix(out.String(), "\r\n"), "\r\n")
+	want := []string{"00:09", "00:06", "00:03", "00:00"}
+	if strings.Join(frames, " ") != strings.Join(want, " ") {
+		t.Errorf("frames = %v, want %v", frames, want)
+	}
+}
+
+// TestIntegrationOutputEveryTickByDefault verifies that every countdown frame is printed without a throttle.
+// Test logic: Cooks 00:03 on a fake clock with output captured and checks all four frames
+// 00:03, 00:02, 00:01 and 00:00 were printed.
+func TestIntegrationOutputEveryTickByDefault(t *testing.T) {
+	var out bytes.Buffer
+	clock := newFakeClock()
+	m := New(WithClock(clock), WithOutput(&out))
+	m.SetDuration(3 * time.Second)
+	out.Reset()
+
+	done := make(chan struct{})
+	go func() {
+		m.PressStart(context.Background())
+		close(done)
+	}()
+	clock.Tick(t, 3)
+	<-done
+
+	if got, want := out.String(), "00:03\r\n00:02\r\n00:01\r\n00:00\r\n"; got != want {
+		t.Errorf("output = %q, want %q", got, want)
+	}
+}
+
+// TestIntegrationStartWithAttributes verifies that per-cook attributes only tag that cook's telemetry.
+// Test logic: Runs one 1 second cook with StartWithAttributes(user_id=alice) and one with
+// PressStart on a fake clock, then checks the cooking_sessions metric has one point tagged
+// alice and one untagged, and only the first cooking_session span has the user_id attribute.
+func TestIntegrationStartWithAttributes(t *testing.T) {
+	reader := sdkmetric.NewManualReader()
+	mp := sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))
+	clock := newFakeClock()
+	m := New(WithClock(clock), WithMeter(mp.Meter("test")), WithInMemoryTracing())
+	alice := attribute.String("user_id", "alice")
+
+	// cook runs a 1 second cook started by start
+	cook := func(start func()) {
+		m.PressDigit(1)
+		done := make(chan struct{})
+		go func() {
+			start()
+			close(done)
+		}()
+		clock.Tick(t, 1)
+		<-done
+	}
+	cook(func() { m.StartWithAttributes(context.Background(), alice) })
+	cook(func() { m.PressStart(context.Background()) })
+
+	// One session point carries the attribute, the other doesn't
+	var rm metricdata.ResourceMetrics
+	if err := reader.Collect(context.Background(), &rm); err != nil {
+		t.Fatalf("failed to collect metrics: %v", err)
+	}
+	var tagged, untagged int64
+	for _, sm := range rm.ScopeMetrics {
+		for _, mt := range sm.Metrics {
+			if mt.Name != "microwave.cooking_sessions" {
+				continue
+			}
+			for _, dp := range mt.Data.(metricdata.Sum[int64]).DataPoints {
+				if v, ok := dp.Attributes.Value("user_id"); ok && v.AsString() == "alice" {
+					tagged += dp.Value
+				} else {
+					untagged += dp.Value
+				}
+			}
+		}
+	}
+	if tagged != 1 || untagged != 1 {
+		t.Errorf("cooking_sessions tagged=%d untagged=%d, want 1 and 1", tagged, untagged)
+	}
+
+	// Only the first span carries the attribute
+	spans := m.RecordedSpans()
+	if len(spans) != 2 {
+		t.Fatalf("expected 2 spans, got %d", len(spans))
+	}
+	for i, span := range spans {
+		has := false
+		for _, kv := range span.Attributes {
+			if kv == alice {
+				has = true
+			}
+		}
+		if has != (i == 0) {
+			t.Errorf("span %d has user_id=%v, want %v", i, has, i == 0)
+		}
+	}
+}
+
+// TestIntegrationStatusDuringCook verifies that Status reports timing while cooking.
+// Test logic: Starts a 00:05 cook on a fake clock, ticks 2 seconds, and checks Status shows
+// cooking with 2 seconds elapsed, 3 remaining, 00:03 on the display and the session ID.
+func TestIntegrationStatusDuringCook(t *testing.T) {
+	clock := newFakeClock()
+	m := New(WithClock(clock), WithIDGenerator(func() string { return "session-1" }))
+	m.SetDuration(5 * time.Second)
+
+	done := make(chan struct{})
+	go func() {
+		m.PressStart(context.Background())
+		close(done)
+	}()
+	clock.Tick(t, 2)
+	clock.BlockUntil(t, 1)
+
+	want := Status{Display: "00:03", Cooking: true, DigitCount: 4, ElapsedSeconds: 2, RemainingSeconds: 3, SessionID: "session-1"}
+	if got := m.Status(); got != want {
+		t.Errorf("Status() = %+v, want %+v", got, want)
+	}
+
+	m.CancelCook()
+	<-done
+}
+
+// TestIntegrationStatusFrozenOnFakeClock verifies that a cook makes no progress while the fake clock stands still.
+// Test logic: Starts a 00:10 cook with a warm-up, heartbeat and display throttle on a fake clock,
+// ticks 3 seconds past the warm-up, and checks Status at that point. Then lets real time pass
+// and checks Status, the printed frames and the pending timers are all unchanged.
+func TestIntegrationStatusFrozenOnFakeClock(t *testing.T) {
+	var out bytes.Buffer
+	clock := newFakeClock()
+	m := New(
+		WithClock(clock),
+		WithOutput(&out),
+		WithIDGenerator(func() string { return "session-1" }),
+		WithWarmup(time.Second),
+		WithHeartbeat(2*time.Second),
+		WithDisplayThrottle(500*time.Millisecond),
+	)
+	m.SetDuration(10 * time.Second)
+	out.Reset()
+
+	done := make(chan struct{})
+	go func() {
+		m.PressStart(context.Background())
+		close(done)
+	}()
+	clock.Tick(t, 4)
+	clock.BlockUntil(t, 1)
+
+	want := Status{Display: "00:07", Cooking: true, DigitCount: 4, ElapsedSeconds: 4, RemainingSeconds: 7, SessionID: "session-1"}
+	if got := m.Status(); got != want {
+		t.Fatalf("Status() = %+v, want %+v", got, want)
+	}
+	frames := out.String()
+
+	// Real time passing doesn't move the cook along
+	time.Sleep(50 * time.Millisecond)
+	if got := m.Status(); got != want {
+		t.Errorf("Status() = %+v after real time passed, want %+v", got, want)
+	}
+	if out.String() != frames {
+		t.Errorf("frames = %q after real time passed, want %q", out.String(), frames)
+	}
+	clock.mu.Lock()
+	pending := len(clock.waiters)
+	clock.mu.Unlock()
+	if pending != 1 {
+		t.Errorf("%d timers pending, want 1", pending)
+	}
+
+	m.CancelCook()
+	<-done
+}
+
+// TestIntegrationStatusFieldsAgree verifies that every Status snapshot pairs a display with its own remaining time.
+// Test logic: Starts a 00:10 cook on a fake clock and polls Status from another goroutine
+// while ticking through the whole cook, checking each snapshot taken while cooking shows
+// the same number of seconds on the display as in RemainingSeconds.
+func TestIntegrationStatusFieldsAgree(t *testing.T) {
+	clock := newFakeClock()
+	m := New(WithClock(clock), WithOutput(io.Discard))
+	m.SetDuration(10 * time.Second)
+
+	done := make(chan struct{})
+	go func() {
+		m.PressStart(context.Background())
+		close(done)
+	}()
+
+	// Poll as fast as possible until the cook ends
+	polled := make(chan []Status)
+	go func() {
+		var mismatched []Status
+		for {
+			select {
+			case <-done:
+				polled <- mismatched
+				return
+			default:
+			}
+			s := m.Status()
+			if s.Cooking && s.Display != fmt.Sprintf("00:%02d", s.RemainingSeconds) {
+				mismatched = append(mismatched, s)
+			}
+		}
+	}()
+
+	clock.Tick(t, 10)
+	<-done
+	if mismatched := <-polled; len(mismatched) > 0 {
+		t.Errorf("%d snapshots disagree, first %+v", len(mismatched), mismatched[0])
+	}
+}
+
+// TestIntegrationRemainingAtCancelHistogram verifies that canceling a cook records the time left.
+// Test logic: Starts a 00:10 cook on a fake clock with a manual metric reader, ticks 2
+// seconds, cancels it, and checks remaining_at_cancel has a single observation of 8.
+// A second cook that runs to completion must not add an observation.
+func TestIntegrationRemainingAtCancelHistogram(t *testing.T) {
+	reader := sdkmetric.NewManualReader()
+	mp := sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))
+	clock := newFakeClock()
+	m := New(WithClock(clock), WithMeter(mp.Meter("test")))
+
+	// Cancel a 10 second cook after 2 ticks
+	m.SetDuration(10 * time.Second)
+	done := make(chan struct{})
+	go func() {
+		m.PressStart(context.Background())
+		close(done)
+	}()
+	clock.Tick(t, 2)
+	clock.BlockUntil(t, 1)
+	m.CancelCook()
+	<-done
+
+	// Let a 1 second cook complete. The canceled cook's timer is still
+	// pending on the fake clock, so wait for the new one alongside it.
+	m.SetDuration(1 * time.Second)
+	done = make(chan struct{})
+	go func() {
+		m.PressStart(context.Background())
+		close(done)
+	}()
+	clock.BlockUntil(t, 2)
+	clock.Advance(1 * time.Second)
+	<-done
+
+	var rm metricdata.ResourceMetrics
+	if err := reader.Collect(context.Background(), &rm); err != nil {
+		t.Fatalf("failed to collect metrics: %v", err)
+	}
+	var count uint64
+	var sum int64
+	for _, sm := range rm.ScopeMetrics {
+		for _, mt := range sm.Metrics {
+			if mt.Name != "microwave.remaining_at_cancel" {
+				continue
+			}
+			for _, dp := range mt.Data.(metricdata.Histogram[int64]).DataPoints {
+				count += dp.Count
+				sum += dp.Sum
+			}
+		}
+	}
+	if count != 1 || sum != 8 {
+		t.Errorf("remaining_at_cancel count=%d sum=%d, want 1 observation of 8", count, sum)
+	}
+}
+
+// TestIntegrationCookingBudget verifies that cooks are refused once the budget is used up.
+// Test logic: With a 2 second budget on a fake clock, completes a 3 second cook, then
+// presses start on a second cook and checks it is refused without cooking, logging
+// "cooking budget exhausted", recording budget_rejections and reporting BudgetExhausted.
+func TestIntegrationCookingBudget(t *testing.T) {
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewJSONHandler(&buf, nil))
+	reader := sdkmetric.NewManualReader()
+	mp := sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))
+	clock := newFakeClock()
+	var got []rejection
+	m := New(
+		WithLogger(logger),
+		WithClock(clock),
+		WithMeter(mp.Meter("test")),
+		WithCookingBudget(2*time.Second),
+		recordRejections(&got),
+	)
+
+	// The first cook starts under budget and runs to completion
+	m.SetDuration(3 * time.Second)
+	done := make(chan struct{})
+	go func() {
+		m.PressStart(context.Background())
+		close(done)
+	}()
+	clock.Tick(t, 3)
+	<-done
+
+	// The second cook is refused and returns without blocking
+	m.SetDuration(3 * time.Second)
+	m.PressStart(context.Background())
+	if m.IsCooking() {
+		t.Error("second cook should be refused")
+	}
+
+	if !strings.Contains(buf.String(), "cooking budget exhausted") {
+		t.Error("expected 'cooking budget exhausted' in logs")
+	}
+	if len(got) != 1 || got[0].reason != BudgetExhausted {
+		t.Errorf("rejections = %v, want one BudgetExhausted", got)
+	}
+
+	var rm metricdata.ResourceMetrics
+	if err := reader.Collect(context.Background(), &rm); err != nil {
+		t.Fatalf("failed to collect metrics: %v", err)
+	}
+	if n := counterValue(rm, "microwave.budget_rejections"); n != 1 {
+		t.Errorf("budget_rejections = %d, want 1", n)
+	}
+	if n := counterValue(rm, "microwave.cooking_sessions"); n != 1 {
+		t.Errorf("cooking_sessions = %d, want 1", n)
+	}
+}
+
+// TestIntegrationCookingBudgetExcludesWarmup verifies that warm-up isn't counted as time cooked.
+// Test logic: With a 4 second budget and a 2 second warm-up on a fake clock, completes a 3
+// second cook and checks it reports 5 elapsed seconds but WriteMetrics counts 3 cooked, then
+// checks a second cook still starts under the budget.
+func TestIntegrationCookingBudgetExcludesWarmup(t *testing.T) {
+	clock := newFakeClock()
+	var got []rejection
+	m := New(
+		WithClock(clock),
+		WithOutput(io.Discard),
+		WithWarmup(2*time.Second),
+		WithCookingBudget(4*time.Second),
+		recordRejections(&got),
+	)
+
+	m.SetDuration(3 * time.Second)
+	done := make(chan struct{})
+	go func() {
+		m.PressStart(context.Background())
+		close(done)
+	}()
+	clock.BlockUntil(t, 1)
+	clock.Advance(2 * time.Second)
+	clock.Tick(t, 3)
+	<-done
+
+	// The warm-up is part of the cook's elapsed time, not of the time cooked
+	if last, _ := m.LastCook(); last.ElapsedSeconds != 5 {
+		t.Errorf("LastCook().ElapsedSeconds = %d, want 5", last.ElapsedSeconds)
+	}
+	var sb strings.Builder
+	if err := m.WriteMetrics(&sb); err != nil {
+		t.Fatalf("WriteMetrics() error = %v", err)
+	}
+	if want := "microwave_cooked_seconds_total 3\n"; !strings.Contains(sb.String(), want) {
+		t.Errorf("WriteMetrics() output missing %q:\n%s", want, sb.String())
+	}
+
+	// 3 of the 4 seconds are used, so another cook starts
+	m.SetDuration(3 * time.Second)
+	done = make(chan struct{})
+	go func() {
+		m.PressStart(context.Background())
+		close(done)
+	}()
+	for !m.IsCooking() {
+		time.Sleep(time.Millisecond)
+	}
+	m.CancelCook()
+	<-done
+	if len(got) != 0 {
+		t.Errorf("rejections = %v, want none", got)
+	}
+}
+
+// TestIntegrationDisplayWithoutSeparatorConcurrent verifies the bare display format under concurrent access.
+// Test logic: With an empty separator, runs 50 writers calling PressDigit and 50 readers
+// calling Display, and checks every read is four digits that parse with the microwave's
+// separator.
+func TestIntegrationDisplayWithoutSeparatorConcurrent(t *testing.T) {
+	m := New(WithSeparator(""), WithOutput(io.Discard))
+
+	var wg sync.WaitGroup
+	errs := make(chan string, 50)
+	for i := range 50 {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			m.PressDigit(i % 10)
+		}()
+		go func() {
+			defer wg.Done()
+			display := m.Display()
+			if _, err := ParseDisplayWithSeparator(display, m.Separator()); err != nil || len(display) != 4 {
+				errs <- display
+			}
+		}()
+	}
+	wg.Wait()
+	close(errs)
+
+	for display := range errs {
+		t.Errorf("invalid bare display %q", display)
+	}
+}
+
+// TestIntegrationMetricAttributeLimit verifies that the cooking_sessions metric's cardinality is capped.
+// Test logic: With a limit of 2, runs 5 one-second cooks on a fake clock, each tagged with a
+// different user_id, and checks the metric has exactly 3 data points (2 users plus other)
+// while the spans keep all 5 original values.
+func TestIntegrationMetricAttributeLimit(t *testing.T) {
+	reader := sdkmetric.NewManualReader()
+	mp := sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))
+	clock := newFakeClock()
+	m := New(WithClock(clock), WithMeter(mp.Meter("test")), WithInMemoryTracing(), WithMetricAttributeLimit(2))
+
+	for i := range 5 {
+		m.SetDuration(1 * time.Second)
+		done := make(chan struct{})
+		go func() {
+			m.StartWithAttributes(context.Background(), attribute.String("user_id", fmt.Sprintf("user-%d", i)))
+			close(done)
+		}()
+		clock.Tick(t, 1)
+		<-done
+	}
+
+	var rm metricdata.ResourceMetrics
+	if err := reader.Collect(context.Background(), &rm); err != nil {
+		t.Fatalf("failed to collect metrics: %v", err)
+	}
+	points := 0
+	for _, sm := range rm.ScopeMetrics {
+		for _, mt := range sm.Metrics {
+			if mt.Name == "microwave.cooking_sessions" {
+				points += len(mt.Data.(metricdata.Sum[int64]).DataPoints)
+			}
+		}
+	}
+	if points != 3 {
+		t.Errorf("cooking_sessions has %d data points, want 3", points)
+	}
+	if n := counterValue(rm, "microwave.cooking_sessions"); n != 5 {
+		t.Errorf("cooking_sessions = %d, want 5", n)
+	}
+
+	// Spans are not limited
+	users := make(map[string]bool)
+	for _, span := range m.RecordedSpans() {
+		for _, kv := range span.Attributes {
+			if kv.Key == "user_id" {
+				users[kv.Value.AsString()] = true
+			}
+		}
+	}
+	if len(users) != 5 || users["other"] {
+		t.Errorf("span user_ids = %v, want 5 original values", users)
+	}
+}
+
+// TestIntegrationLastCook verifies that LastCook summarizes the most recent finished cook.
+// Test logic: Checks LastCook reports nothing before any cook, then runs a 00:03 cook to
+// completion on a fake clock and checks the summary, then cancels a 00:05 cook after 1
+// second and checks LastCook now describes the canceled cook.
+func TestIntegrationLastCook(t *testing.T) {
+	clock := newFakeClock()
+	ids := []string{"first", "second"}
+	m := New(WithClock(clock), WithIDGenerator(func() string {
+		id := ids[0]
+		ids = ids[1:]
+		return id
+	}))
+
+	if _, ok := m.LastCook(); ok {
+		t.Error("LastCook() should report nothing before the first cook")
+	}
+
+	// A completed cook
+	m.SetDuration(3 * time.Second)
+	done := make(chan struct{})
+	go func() {
+		m.PressStart(context.Background())
+		close(done)
+	}()
+	clock.Tick(t, 3)
+	<-done
+
+	want := CookResult{Completed: true, SessionID: "first", RequestedSeconds: 3, ElapsedSeconds: 3, EndedAt: clock.Now()}
+	if got, ok := m.LastCook(); !ok || got != want {
+		t.Errorf("LastCook() = %+v, %v; want %+v", got, ok, want)
+	}
+
+	// A canceled cook replaces it
+	m.SetDuration(5 * time.Second)
+	done = make(chan struct{})
+	go func() {
+		m.PressStart(context.Background())
+		close(done)
+	}()
+	clock.Tick(t, 1)
+	clock.BlockUntil(t, 1)
+	m.CancelCook()
+	<-done
+
+	want = CookResult{Completed: false, SessionID: "second", RequestedSeconds: 5, ElapsedSeconds: 1, EndedAt: clock.Now()}
+	if got, ok := m.LastCook(); !ok || got != want {
+		t.Errorf("LastCook() = %+v, %v; want %+v", got, ok, want)
+	}
+}
+
+// TestIntegrationHeartbeat verifies that WithHeartbeat logs progress at its interval during a cook.
+// Test logic: Cooks 00:10 with a 100ms logical second and a 300ms heartbeat on a fake clock,
+// logging at info level, and checks exactly three "cooking in progress" lines were logged
+// with 7, 4 and 1 seconds remaining, and that no debug ticks were needed to see them.
+func TestIntegrationHeartbeat(t *testing.T) {
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewJSONHandler(&buf, nil))
+	clock := newFakeClock()
+	m := New(
+		WithLogger(logger),
+		WithClock(clock),
+		WithOutput(io.Discard),
+		WithLogicalSecond(100*time.Millisecond),
+		WithHeartbeat(300*time.Millisecond),
+	)
+	m.SetDuration(10 * time.Second)
+
+	done := make(chan struct{})
+	go func() {
+		m.PressStart(context.Background())
+		close(done)
+	}()
+	for range 10 {
+		clock.BlockUntil(t, 1)
+		clock.Advance(100 * time.Millisecond)
+	}
+	<-done
+
+	// Collect the remaining time from each heartbeat
+	var remaining []int
+	for _, line := range strings.Split(strings.TrimSpace(buf.String()), "\n") {
+		var entry struct {
+			Msg       string `json:"msg"`
+			Level     string `json:"level"`
+			Remaining int    `json:"remaining"`
+		}
+		if err := json.Unmarshal([]byte(line), &entry); err != nil {
+			t.Fatalf("invalid log line %q: %v", line, err)
+		}
+		if entry.Msg == "tick" {
+			t.Error("debug tick logged with the default info level")
+		}
+		if entry.Msg == "cooking in progress" {
+			if entry.Level != "INFO" {
+				t.Errorf("heartbeat level = %s, want INFO", entry.Level)
+			}
+			remaining = append(remaining, entry.Remaining)
+		}
+	}
+
+	want := []int{7, 4, 1}
+	if fmt.Sprint(remaining) != fmt.Sprint(want) {
+		t.Errorf("heartbeat remaining = %v, want %v", remaining, want)
+	}
+}
+
+// TestIntegrationNoHeartbeatByDefault verifies that no heartbeat is logged without WithHeartbeat.
+// Test logic: Cooks 00:05 on a fake clock and checks the logs never mention "cooking in progress".
+func TestIntegrationNoHeartbeatByDefault(t *testing.T) {
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewJSONHandler(&buf, nil))
+	clock := newFakeClock()
+	m := New(WithLogger(logger), WithClock(clock), WithOutput(io.Discard))
+	m.SetDuration(5 * time.Second)
+
+	done := make(chan struct{})
+	go func() {
+		m.PressStart(context.Background())
+		close(done)
+	}()
+	clock.Tick(t, 5)
+	<-done
+
+	if strings.Contains(buf.String(), "cooking in progress") {
+		t.Errorf("unexpected heartbeat without WithHeartbeat:\n%s", buf.String())
+	}
+}
+
+// TestIntegrationTestTelemetryBundle verifies that a cook is visible in all three signals of the test bundle.
+// Test logic: Wires a telemetry.NewTestTelemetry bundle into a microwave, cooks 00:02 on a
+// fake clock, then checks a "cooking_session" span with the session ID was exported, the
+// cooking_sessions counter is 1, and "cooking started" and "cooking complete" were logged.
+func TestIntegrationTestTelemetryBundle(t *testing.T) {
+	tel := telemetry.NewTestTelemetry()
+	clock := newFakeClock()
+	m := New(
+		WithLogger(tel.Logger),
+		WithTracer(tel.Tracer),
+		WithMeter(tel.Meter),
+		WithClock(clock),
+		WithOutput(io.Discard),
+		WithIDGenerator(func() string { return "bundle" }),
+	)
+
+	m.SetDuration(2 * time.Second)
+	done := make(chan struct{})
+	go func() {
+		m.PressStart(context.Background())
+		close(done)
+	}()
+	clock.Tick(t, 2)
+	<-done
+
+	// Trace
+	spans := tel.Spans.GetSpans()
+	if len(spans) != 1 || spans[0].Name != "cooking_session" {
+		t.Fatalf("spans = %v, want one cooking_session span", spans)
+	}
+	found := false
+	for _, kv := range spans[0].Attributes {
+		if kv.Key == "session_id" && kv.Value.AsString() == "bundle" {
+			found = true
+		}
+	}
+	if !found {
+		t.Errorf("span attributes %v missing session_id=bundle", spans[0].Attributes)
+	}
+
+	// Metric
+	rm, err := tel.Collect(context.Background())
+	if err != nil {
+		t.Fatalf("failed to collect metrics: %v", err)
+	}
+	if got := counterValue(rm, "microwave.cooking_sessions"); got != 1 {
+		t.Errorf("cooking_sessions = %d, want 1", got)
+	}
+
+	// Log
+	logs := tel.Logs()
+	for _, want := range []string{`"msg":"cooking started"`, `"msg":"cooking complete"`} {
+		if !strings.Contains(logs, want) {
+			t.Errorf("logs missing %s:\n%s", want, logs)
+		}
+	}
+}
+
+// TestIntegrationDoubleTapStart verifies that WithDoubleTapStart only cooks after two presses within the window.
+// Test logic: With a 1s window on a fake clock, presses start once and checks nothing cooks
+// and a debug log explains why. Presses again 2s later (outside the window) and checks it
+// is treated as a new first tap, then presses a third time 500ms later and checks the cook
+// runs to completion.
+func TestIntegrationDoubleTapStart(t *testing.T) {
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewJSONHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug}))
+	clock := newFakeClock()
+	m := New(
+		WithLogger(logger),
+		WithClock(clock),
+		WithOutput(io.Discard),
+		WithDoubleTapStart(time.Second),
+	)
+	m.SetDuration(2 * time.Second)
+
+	// A single press is ignored
+	m.PressStart(context.Background())
+	if _, ok := m.LastCook(); ok || m.IsCooking() {
+		t.Fatal("a single start press should not cook")
+	}
+	if !strings.Contains(buf.String(), "start ignored, press again to confirm") {
+		t.Errorf("expected a debug log for the ignored press:\n%s", buf.String())
+	}
+
+	// A press outside the window starts over
+	clock.Advance(2 * time.Second)
+	m.PressStart(context.Background())
+	if _, ok := m.LastCook(); ok || m.IsCooking() {
+		t.Fatal("a press after the window should not cook")
+	}
+
+	// A second press within the window cooks
+	clock.Advance(500 * time.Millisecond)
+	done := make(chan struct{})
+	go func() {
+		m.PressStart(context.Background())
+		close(done)
+	}()
+	clock.Tick(t, 2)
+	<-done
+
+	if got, ok := m.LastCook(); !ok || !got.Completed || got.RequestedSeconds != 2 {
+		t.Errorf("LastCook() = %+v, %v; want a completed 2 second cook", got, ok)
+	}
+}
+
+// TestIntegrationCookQueue verifies that a queued cook starts automatically after the current one.
+// Test logic: With the cook queue on, starts a 2 second cook on a fake clock and queues 3
+// seconds, checking a second queue attempt is refused with QueueFull. Ticks through both
+// cooks and checks the completion callback saw the 2 second cook followed by the queued
+// 3 second one, that the microwave stayed cooking in between, and ends idle at 00:00.
+func TestIntegrationCookQueue(t *testing.T) {
+	var rejected []rejection
+	var results []CookResult
+	clock := newFakeClock()
+	m := New(
+		WithClock(clock),
+		WithOutput(io.Discard),
+		WithCookQueue(true),
+		recordRejections(&rejected),
+		WithOnComplete(func(r CookResult) { results = append(results, r) }),
+	)
+
+	m.SetDuration(2 * time.Second)
+	done := make(chan struct{})
+	go func() {
+		m.PressStart(context.Background())
+		close(done)
+	}()
+	clock.BlockUntil(t, 1)
+
+	// One cook can be queued, a second is refused
+	if !m.QueueTime(3) {
+		t.Fatal("QueueTime(3) = false during a cook, want true")
+	}
+	if m.QueueTime(4) {
+		t.Error("QueueTime(4) = true with a cook already queued, want false")
+	}
+	if len(rejected) != 1 || rejected[0].reason != QueueFull {
+		t.Errorf("rejections = %v, want one QueueFull", rejected)
+	}
+
+	// Finish the first cook; the queued one takes over the display
+	clock.Tick(t, 2)
+	clock.BlockUntil(t, 1)
+	if !m.IsCooking() {
+		t.Error("IsCooking() = false while the queued cook runs")
+	}
+	if got := m.Display(); got != "00:03" {
+		t.Errorf("Display() = %s at the start of the queued cook, want 00:03", got)
+	}
+
+	clock.Tick(t, 3)
+	<-done
+
+	if len(results) != 2 {
+		t.Fatalf("got %d cook results, want 2: %+v", len(results), results)
+	}
+	if !results[0].Completed || results[0].RequestedSeconds != 2 {
+		t.Errorf("first cook = %+v, want a completed 2 second cook", results[0])
+	}
+	if !results[1].Completed || results[1].RequestedSeconds != 3 {
+		t.Errorf("queued cook = %+v, want a completed 3 second cook", results[1])
+	}
+	if m.IsCooking() || m.Display() != "00:00" {
+		t.Errorf("after both cooks IsCooking() = %v, Display() = %s; want false, 00:00", m.IsCooking(), m.Display())
+	}
+}
+
+// TestIntegrationCookQueueDroppedOnCancel verifies that canceling a cook drops the queued one.
+// Test logic: Queues a cook during a running cook, cancels the running cook, and checks
+// PressStart returns with only the canceled cook reported and the microwave idle.
+func TestIntegrationCookQueueDroppedOnCancel(t *testing.T) {
+	var results []CookResult
+	clock := newFakeClock()
+	m := New(
+		WithClock(clock),
+		WithOutput(io.Discard),
+		WithCookQueue(true),
+		WithOnComplete(func(r CookResult) { results = append(results, r) }),
+	)
+
+	m.SetDuration(5 * time.Second)
+	done := make(chan struct{})
+	go func() {
+		m.PressStart(context.Background())
+		close(done)
+	}()
+	clock.BlockUntil(t, 1)
+	m.QueueTime(3)
+
+	m.CancelCook()
+	<-done
+
+	if len(results) != 1 || results[0].Completed {
+		t.Errorf("results = %+v, want only the canceled cook", results)
+	}
+	if m.IsCooking() {
+		t.Error("IsCooking() = true after canceling, want the queued cook dropped")
+	}
+}
+
+// TestIntegrationEstimatedCompletion verifies that EstimatedCompletion scales the remaining time by the logical second.
+// Test logic: Cooks 00:10 with a 100ms logical second on a fake clock and checks the estimate
+// is 1s after the start, still the same instant after three ticks, and unavailable once the
+// cook completes.
+func TestIntegrationEstimatedCompletion(t *testing.T) {
+	clock := newFakeClock()
+	m := New(WithClock(clock), WithOutput(io.Discard), WithLogicalSecond(100*time.Millisecond))
+	m.SetDuration(10 * time.Second)
+
+	done := make(chan struct{})
+	go func() {
+		m.PressStart(context.Background())
+		close(done)
+	}()
+	clock.BlockUntil(t, 1)
+
+	// 10 displayed seconds of 100ms each
+	want := clock.Now().Add(time.Second)
+	if got, ok := m.EstimatedCompletion(); !ok || !got.Equal(want) {
+		t.Errorf("EstimatedCompletion() = %v, %v at the start; want %v, true", got, ok, want)
+	}
+
+	// Three ticks later the finish time hasn't moved
+	for range 3 {
+		clock.Advance(100 * time.Millisecond)
+		clock.BlockUntil(t, 1)
+	}
+	if got, ok := m.EstimatedCompletion(); !ok || !got.Equal(want) {
+		t.Errorf("EstimatedCompletion() = %v, %v after 3 ticks; want %v, true", got, ok, want)
+	}
+
+	// Run out the remaining 7 ticks
+	for range 6 {
+		clock.Advance(100 * time.Millisecond)
+		clock.BlockUntil(t, 1)
+	}
+	clock.Advance(100 * time.Millisecond)
+	<-done
+	if _, ok := m.EstimatedCompletion(); ok {
+		t.Error("EstimatedCompletion() = true after the cook completed, want false")
+	}
+}
+
+// TestIntegrationSkipToEnd verifies that SkipToEnd finishes a cook as a normal completion.
+// Test logic: Starts a 10 second cook on a fake clock, calls SkipToEnd before any time passes,
+// and checks the cook returns without the clock advancing, is reported as completed through
+// OnComplete and the logs, ends on 00:00, and that a second SkipToEnd returns false.
+func TestIntegrationSkipToEnd(t *testing.T) {
+	var buf bytes.Buffer
+	var out bytes.Buffer
+	var results []CookResult
+	clock := newFakeClock()
+	m := New(
+		WithLogger(slog.New(slog.NewJSONHandler(&buf, nil))),
+		WithClock(clock),
+		WithOutput(&out),
+		WithOnComplete(func(r CookResult) { results = append(results, r) }),
+	)
+
+	m.SetDuration(10 * time.Second)
+	done := make(chan struct{})
+	go func() {
+		m.PressStart(context.Background())
+		close(done)
+	}()
+	clock.BlockUntil(t, 1)
+
+	// Skipping ends the cook without waiting for the clock
+	if !m.SkipToEnd() {
+		t.Error("SkipToEnd() = false, want true while cooking")
+	}
+	<-done
+
+	if len(results) != 1 || !results[0].Completed || results[0].RequestedSeconds != 10 {
+		t.Fatalf("results = %+v, want one completed 10 second cook", results)
+	}
+	logs := buf.String()
+	for _, want := range []string{"cook skipped to end", "cooking complete"} {
+		if !strings.Contains(logs, want) {
+			t.Errorf("expected %q in logs", want)
+		}
+	}
+	if strings.Contains(logs, "cooking canceled") {
+		t.Error("skipped cook was logged as canceled")
+	}
+	if !strings.HasSuffix(out.String(), "00:00\r\n") {
+		t.Errorf("last frame of %q, want 00:00", out.String())
+	}
+	if m.IsCooking() || m.RemainingSeconds() != 0 {
+		t.Errorf("IsCooking() = %v, RemainingSeconds() = %d after skipping, want idle at 0", m.IsCooking(), m.RemainingSeconds())
+	}
+
+	// Nothing left to skip
+	if m.SkipToEnd() {
+		t.Error("second SkipToEnd() = true, want false")
+	}
+}
+
+// TestIntegrationSkipToEndBetweenTicks verifies that a skip arriving after the cook has ticked still ends it.
+// Test logic: Starts a 10 second cook on a fake clock, lets one second pass so the countdown
+// is between ticks, then calls SkipToEnd and checks the cook completes without the clock
+// advancing again. Repeats to catch the skip landing anywhere in the countdown loop.
+func TestIntegrationSkipToEndBetweenTicks(t *testing.T) {
+	for i := 0; i < 20; i++ {
+		var results []CookResult
+		clock := newFakeClock()
+		m := New(
+			WithClock(clock),
+			WithOutput(io.Discard),
+			WithOnComplete(func(r CookResult) { results = append(results, r) }),
+		)
+
+		m.SetDuration(10 * time.Second)
+		done := make(chan struct{})
+		go func() {
+			m.PressStart(context.Background())
+			close(done)
+		}()
+
+		// One tick, then skip without waiting for the next wait to begin
+		clock.Tick(t, 1)
+		if !m.SkipToEnd() {
+			t.Fatal("SkipToEnd() = false, want true while cooking")
+		}
+
+		// The clock never moves again, so only the skip can end the cook
+		select {
+		case <-done:
+		case <-time.After(2 * time.Second):
+			t.Fatal("cook did not end after SkipToEnd")
+		}
+		if len(results) != 1 || !results[0].Completed {
+			t.Fatalf("results = %+v, want one completed cook", results)
+		}
+		if got := m.RemainingSeconds(); got != 0 {
+			t.Errorf("RemainingSeconds() = %d after skipping, want 0", got)
+		}
+	}
+}
+
+// TestIntegrationStartIdempotency verifies that a repeated start within the window cooks only once.
+// Test logic: With a one minute window on a fake clock, starts a 00:03 cook tagged with a request
+// ID and repeats the same start while it cooks and again after it completes, checking both are
+// ignored with the first cook's session ID while a start with a different ID is refused as
+// AlreadyCooking. Once the window has passed the same start cooks again under a new session.
+func TestIntegrationStartIdempotency(t *testing.T) {
+	var buf bytes.Buffer
+	var results []CookResult
+	var rejected []rejection
+	clock := newFakeClock()
+	m := New(
+		WithLogger(slog.New(slog.NewJSONHandler(&buf, nil))),
+		WithClock(clock),
+		WithOutput(io.Discard),
+		WithStartIdempotency(time.Minute),
+		WithOnComplete(func(r CookResult) { results = append(results, r) }),
+		recordRejections(&rejected),
+	)
+	request := attribute.String("request_id", "r1")
+
+	m.SetDuration(3 * time.Second)
+	done := make(chan struct{})
+	go func() {
+		m.StartWithAttributes(context.Background(), request)
+		close(done)
+	}()
+	clock.BlockUntil(t, 1)
+	sessionID := m.Status().SessionID
+	if sessionID == "" {
+		t.Fatal("Status().SessionID is empty while cooking")
+	}
+
+	// A retry while cooking is ignored, a different request is refused
+	m.StartWithAttributes(context.Background(), request)
+	m.StartWithAttributes(context.Background(), attribute.String("request_id", "r2"))
+	if len(rejected) != 1 || rejected[0].reason != AlreadyCooking {
+		t.Errorf("rejections = %+v, want only the different request refused as AlreadyCooking", rejected)
+	}
+
+	clock.Tick(t, 3)
+	<-done
+
+	// A retry after the cook finished is still ignored; it returns instead of cooking
+	m.SetDuration(3 * time.Second)
+	m.StartWithAttributes(context.Background(), request)
+	if len(results) != 1 || results[0].SessionID != sessionID {
+		t.Fatalf("results = %+v, want a single cook with session %s", results, sessionID)
+	}
+
+	var duplicates int
+	for line := range strings.Lines(buf.String()) {
+		var entry struct {
+			Msg       string `json:"msg"`
+			SessionID string `json:"session_id"`
+		}
+		if err := json.Unmarshal([]byte(line), &entry); err == nil && entry.Msg == "duplicate start ignored" {
+			duplicates++
+			if entry.SessionID != sessionID {
+				t.Errorf("duplicate logged session %q, want %q", entry.SessionID, sessionID)
+			}
+		}
+	}
+	if duplicates != 2 {
+		t.Errorf("logged %d duplicate starts, want 2", duplicates)
+	}
+
+	// Outside the window the same start cooks again
+	clock.Advance(time.Minute)
+	done = make(chan struct{})
+	go func() {
+		m.StartWithAttributes(context.Background(), request)
+		close(done)
+	}()
+	clock.BlockUntil(t, 1)
+	m.CancelCook()
+	<-done
+	if len(results) != 2 || results[1].SessionID == sessionID {
+		t.Errorf("results = %+v, want a second cook with a new session", results)
+	}
+}
+
+// TestIntegrationStartIdempotencyDifferentTime verifies that only a start of the same time is a duplicate.
+// Test logic: Runs a 00:02 cook to completion with idempotency on, then enters 01:00 inside the
+// window and checks it cooks. Repeats with AssumeMinutes, where entering 1 cooks 01:00, and checks
+// that entering 1 again is ignored while entering 2 cooks 02:00.
+func TestIntegrationStartIdempotencyDifferentTime(t *testing.T) {
+	var results []CookResult
+	clock := newFakeClock()
+	newMicrowave := func(opts ...Option) *Microwave {
+		results = nil
+		return New(append([]Option{
+			WithClock(clock),
+			WithOutput(io.Discard),
+			WithStartIdempotency(time.Minute),
+			WithOnComplete(func(r CookResult) { results = append(results, r) }),
+		}, opts...)...)
+	}
+	// startAndCancel presses START and cancels the cook once it is running.
+	// Canceled cooks leave their timers on the fake clock, so it waits for
+	// IsCooking rather than a pending timer.
+	startAndCancel := func(m *Microwave) {
+		done := make(chan struct{})
+		go func() {
+			m.PressStart(context.Background())
+			close(done)
+		}()
+		for !m.IsCooking() {
+			time.Sleep(time.Millisecond)
+		}
+		m.CancelCook()
+		<-done
+	}
+
+	m := newMicrowave()
+	m.SetDuration(2 * time.Second)
+	done := make(chan struct{})
+	go func() {
+		m.PressStart(context.Background())
+		close(done)
+	}()
+	clock.BlockUntil(t, 1)
+	clock.Tick(t, 2)
+	<-done
+
+	// A different time inside the window cooks
+	m.PressDigit(1)
+	m.PressDigit(0)
+	m.PressDigit(0)
+	startAndCancel(m)
+	if len(results) != 2 || results[1].RequestedSeconds != 60 {
+		t.Fatalf("results = %+v, want a second cook of 60 seconds", results)
+	}
+
+	// Partial entries are compared as START reads them
+	m = newMicrowave(WithPartialEntryPolicy(AssumeMinutes))
+	m.PressDigit(1)
+	startAndCancel(m)
+	m.PressDigit(1)
+	m.PressStart(context.Background())
+	if len(results) != 1 {
+		t.Fatalf("results = %+v, want the repeated 01:00 start ignored", results)
+	}
+	m.PressClear(context.Background())
+	m.PressDigit(2)
+	startAndCancel(m)
+	if len(results) != 2 || results[1].RequestedSeconds != 120 {
+		t.Errorf("results = %+v, want a second cook of 120 seconds", results)
+	}
+}
+
+// TestIntegrationMinuteRollover verifies that crossing a minute boundary is reported exactly once.
+// Test logic: Cooks 01:01 on a fake clock with in-memory tracing and a rollover callback, ticking
+// through to the end, and checks the callback ran once with 0 minutes, "minute rollover" was
+// logged once with the 00:59 display, and the session span has one minute_rollover event.
+func TestIntegrationMinuteRollover(t *testing.T) {
+	var buf bytes.Buffer
+	var rollovers []int
+	clock := newFakeClock()
+	m := New(
+		WithLogger(slog.New(slog.NewJSONHandler(&buf, nil))),
+		WithClock(clock),
+		WithOutput(io.Discard),
+		WithInMemoryTracing(),
+		WithOnMinuteRollover(func(minutes int) { rollovers = append(rollovers, minutes) }),
+	)
+
+	m.SetDuration(61 * time.Second)
+	done := make(chan struct{})
+	go func() {
+		m.PressStart(context.Background())
+		close(done)
+	}()
+	clock.Tick(t, 61)
+	<-done
+
+	if len(rollovers) != 1 || rollovers[0] != 0 {
+		t.Errorf("rollovers = %v, want [0]", rollovers)
+	}
+	if n := strings.Count(buf.String(), `"msg":"minute rollover"`); n != 1 {
+		t.Errorf("logged %d minute rollovers, want 1", n)
+	}
+	if !strings.Contains(buf.String(), `"display":"00:59","minutes":0`) {
+		t.Error("expected the rollover to be logged with the 00:59 display")
+	}
+
+	spans := m.RecordedSpans()
+	if len(spans) != 1 {
+		t.Fatalf("recorded %d spans, want 1", len(spans))
+	}
+	var events int
+	for _, e := range spans[0].Events {
+		if e.Name == "minute_rollover" {
+			events++
+		}
+	}
+	if events != 1 {
+		t.Errorf("span has %d minute_rollover events, want 1", events)
+	}
+}
+
+// TestIntegrationCookCompletionDelay verifies that 00:00 is held after a cook until the hold ends or is dismissed.
+// Test logic: Cooks 00:03 with a 2 second completion hold on a fake clock. One second into the
+// hold, checks a digit press only dismisses it, leaving 00:00, and the next press is entered.
+// Then cooks again and checks a press after the full 2 seconds is entered right away.
+func TestIntegrationCookCompletionDelay(t *testing.T) {
+	var buf bytes.Buffer
+	clock := newFakeClock()
+	m := New(
+		WithLogger(slog.New(slog.NewJSONHandler(&buf, nil))),
+		WithClock(clock),
+		WithOutput(io.Discard),
+		WithCookCompletionDelay(2*time.Second),
+	)
+	cook := func() {
+		m.SetDuration(3 * time.Second)
+		done := make(chan struct{})
+		go func() {
+			m.PressStart(context.Background())
+			close(done)
+		}()
+		clock.Tick(t, 3)
+		<-done
+	}
+
+	// A press during the hold only dismisses it
+	cook()
+	clock.Advance(time.Second)
+	m.PressDigit(5)
+	if got := m.Display(); got != "00:00" {
+		t.Errorf("Display() = %s after a press during the hold, want 00:00", got)
+	}
+	if !strings.Contains(buf.String(), "completion hold dismissed") {
+		t.Error("expected 'completion hold dismissed' in logs")
+	}
+	m.PressDigit(5)
+	if got := m.Display(); got != "00:05" {
+		t.Errorf("Display() = %s after the hold was dismissed, want 00:05", got)
+	}
+
+	// Once the hold has run out, presses go through
+	cook()
+	clock.Advance(2 * time.Second)
+	m.PressDigit(7)
+	if got := m.Display(); got != "00:07" {
+		t.Errorf("Display() = %s after the hold ran out, want 00:07", got)
+	}
+}
+
+// TestIntegrationClockNormalizationOnStart verifies that an entry like 00:75 is shown as 01:15 once cooking starts.
+// Test logic: Enters 0,0,7,5 with normalization on, starts cooking on a fake clock, and checks
+// the display and the "cooking started" log show 01:15 and the first countdown frame after the
+// entry is 01:15. Then ticks to the end and checks the cook ran for 75 seconds.
+func TestIntegrationClockNormalizationOnStart(t *testing.T) {
+	var buf bytes.Buffer
+	var out bytes.Buffer
+	clock := newFakeClock()
+	m := New(
+		WithLogger(slog.New(slog.NewJSONHandler(&buf, nil))),
+		WithClock(clock),
+		WithOutput(&out),
+		WithClockNormalizationOnStart(true),
+	)
+	for _, d := range []int{0, 0, 7, 5} {
+		m.PressDigit(d)
+	}
+	entry := out.Len()
+
+	done := make(chan struct{})
+	go func() {
+		m.PressStart(context.Background())
+		close(done)
+	}()
+	clock.BlockUntil(t, 1)
+
+	if got := m.Display(); got != "01:15" {
+		t.Errorf("Display() = %s once cooking, want 01:15", got)
+	}
+	if !strings.Contains(buf.String(), `"msg":"cooking started","session_id"`) ||
+		!strings.Contains(buf.String(), `"display":"01:15","seconds":75`) {
+		t.Errorf("expected 'cooking started' logged with 01:15, got %s", buf.String())
+	}
+	if frame := out.String()[entry:]; !strings.HasPrefix(frame, "01:15\r\n") {
+		t.Errorf("first countdown frame = %q, want 01:15", frame)
+	}
+
+	clock.Tick(t, 75)
+	<-done
+	if result, ok := m.LastCook(); !ok || !result.Completed || result.ElapsedSeconds != 75 {
+		t.Errorf("LastCook() = %+v, %v; want a completed 75 second cook", result, ok)
+	}
+}
+
+// TestIntegrationFinalFrame verifies that WithFinalFrame replaces the last countdown frame only.
+// Test logic: Cooks 00:02 on a fake clock with a final frame function that returns "End" plus the
+// microwave's display, and checks the printed frames are 00:02, 00:01 then "End 00:00", the final
+// tick is logged with the custom frame, and Display still reports 00:00.
+func TestIntegrationFinalFrame(t *testing.T) {
+	var buf bytes.Buffer
+	var out bytes.Buffer
+	clock := newFakeClock()
+	m := New(
+		WithLogger(slog.New(slog.NewJSONHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug}))),
+		WithClock(clock),
+		WithOutput(&out),
+		WithFinalFrame(func(m *Microwave) string { return "End " + m.Display() }),
+	)
+	m.SetDuration(2 * time.Second)
+	out.Reset()
+
+	done := make(chan struct{})
+	go func() {
+		m.PressStart(context.Background())
+		close(done)
+	}()
+	clock.Tick(t, 2)
+	<-done
+
+	if got, want := out.String(), "00:02\r\n00:01\r\nEnd 00:00\r\n"; got != want {
+		t.Errorf("frames = %q, want %q", got, want)
+	}
+	if !strings.Contains(buf.String(), `"msg":"tick","display":"End 00:00"`) {
+		t.Error("expected the final tick to be logged with the custom frame")
+	}
+	if got := m.Display(); got != "00:00" {
+		t.Errorf("Display() = %s, want 00:00", got)
+	}
+}
+
+// TestIntegrationCancelCookNonCancelableContext verifies that CancelCook stops cooks started with contexts that can't be canceled.
+// Test logic: For context.Background(), context.TODO() and a context.WithoutCancel of a canceled
+// parent, starts a 10 second cook on a fake clock, calls CancelCook, and checks the cook ends
+// as canceled without the clock advancing.
+func TestIntegrationCancelCookNonCancelableContext(t *testing.T) {
+	canceled, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	tests := []struct {
+		name string
+		ctx  context.Context
+	}{
+		{"Background", context.Background()},
+		{"TODO", context.TODO()},
+		{"WithoutCancel", context.WithoutCancel(canceled)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clock := newFakeClock()
+			results := make(chan CookResult, 1)
+			m := New(
+				WithClock(clock),
+				WithOutput(io.Discard),
+				WithOnComplete(func(r CookResult) { results <- r }),
+			)
+
+			m.SetDuration(10 * time.Second)
+			go m.PressStart(tt.ctx)
+			clock.BlockUntil(t, 1)
+
+			if !m.CancelCook() {
+				t.Fatal("CancelCook() = false, want true while cooking")
+			}
+			if result := <-results; result.Completed {
+				t.Errorf("result = %+v, want a canceled cook", result)
+			}
+		})
+	}
+}
+
+// TestIntegrationWarmup verifies that the display holds the full time during warm-up and then counts down.
+// Test logic: Cooks 00:03 with a 2 second warm-up on a fake clock and checks the display holds
+// 00:03 with "warming up" logged and no frame printed until the warm-up ends, then decrements
+// on the next tick. Then starts another cook, cancels it during warm-up, and checks it stopped
+// without printing a frame.
+func TestIntegrationWarmup(t *testing.T) {
+	var buf bytes.Buffer
+	var out bytes.Buffer
+	clock := newFakeClock()
+	m := New(
+		WithLogger(slog.New(slog.NewJSONHandler(&buf, nil))),
+		WithClock(clock),
+		WithOutput(&out),
+		WithWarmup(2*time.Second),
+	)
+
+	m.SetDuration(3 * time.Second)
+	out.Reset()
+	done := make(chan struct{})
+	go func() {
+		m.PressStart(context.Background())
+		close(done)
+	}()
+
+	// The display holds steady through the warm-up
+	clock.BlockUntil(t, 1)
+	if !strings.Contains(buf.String(), "warming up") {
+		t.Error("expected 'warming up' in logs")
+	}
+	clock.Advance(time.Second)
+	if got := m.Display(); got != "00:03" {
+		t.Errorf("Display() = %s during warm-up, want 00:03", got)
+	}
+	if out.Len() != 0 {
+		t.Errorf("printed %q during warm-up, want nothing", out.String())
+	}
+
+	// Once warmed up, the countdown runs as usual
+	clock.Advance(time.Second)
+	clock.Tick(t, 1)
+	clock.BlockUntil(t, 1)
+	if got := m.Display(); got != "00:02" {
+		t.Errorf("Display() = %s one tick after warm-up, want 00:02", got)
+	}
+	clock.Tick(t, 2)
+	<-done
+	if got := out.String(); got != "00:03\r\n00:02\r\n00:01\r\n00:00\r\n" {
+		t.Errorf("frames = %q, want 00:03 down to 00:00", got)
+	}
+
+	// Canceling during warm-up stops the cook before it counts down
+	ctx, cancel := context.WithCancel(context.Background())
+	m.SetDuration(3 * time.Second)
+	out.Reset()
+	done = make(chan struct{})
+	go func() {
+		m.PressStart(ctx)
+		close(done)
+	}()
+	clock.BlockUntil(t, 1)
+	cancel()
+	<-done
+	if m.IsCooking() {
+		t.Error("still cooking after canceling during warm-up")
+	}
+	if out.Len() != 0 {
+		t.Errorf("printed %q after canceling during warm-up, want nothing", out.String())
+	}
+}
+
+// TestIntegrationPressClear verifies that PressClear both ends a cook and zeroes the digits.
+// Test logic: Starts a 10 second cook on a fake clock, ticks once, presses clear, and checks
+// the cook ended as canceled, "cleared" was logged with the canceled cook, and the display is
+// 00:00 with no digits entered so a new time can be typed right away.
+func TestIntegrationPressClear(t *testing.T) {
+	var buf bytes.Buffer
+	var results []CookResult
+	clock := newFakeClock()
+	m := New(
+		WithLogger(slog.New(slog.NewJSONHandler(&buf, nil))),
+		WithClock(clock),
+		WithOutput(io.Discard),
+		WithOnComplete(func(r CookResult) { results = append(results, r) }),
+	)
+
+	m.SetDuration(10 * time.Second)
+	done := make(chan struct{})
+	go func() {
+		m.PressStart(context.Background())
+		close(done)
+	}()
+	clock.Tick(t, 1)
+	clock.BlockUntil(t, 1)
+
+	m.PressClear(context.Background())
+	<-done
+
+	if len(results) != 1 || results[0].Completed {
+		t.Fatalf("results = %+v, want one canceled cook", results)
+	}
+	if !strings.Contains(buf.String(), `"msg":"cleared","canceled_cook":true`) {
+		t.Error("expected 'cleared' with a canceled cook in logs")
+	}
+	if got := m.Status(); got.Display != "00:00" || got.DigitCount != 0 || got.Cooking {
+		t.Errorf("Status() = %+v after clear, want idle at 00:00 with no digits", got)
+	}
+
+	// The next digit starts a fresh entry
+	m.PressDigit(7)
+	if got := m.Display(); got != "00:07" {
+		t.Errorf("Display() = %s after clear, want 00:07", got)
+	}
+}
+
+// TestIntegrationStartToTemp verifies that a probe cook shows the temperature and stops at the target.
+// Test logic: Cooks to 35°C at 5°C per second on a fake clock and checks the frames climb from
+// 20C to 35C, the cook completed after 3 seconds, and the display shows the time again
+// afterwards. Then checks a target of 20°C is rejected with TargetTooLow.
+func TestIntegrationStartToTemp(t *testing.T) {
+	var buf bytes.Buffer
+	var out bytes.Buffer
+	var got []rejection
+	var results []CookResult
+	clock := newFakeClock()
+	m := New(
+		WithLogger(slog.New(slog.NewJSONHandler(&buf, nil))),
+		WithClock(clock),
+		WithOutput(&out),
+		WithHeatingRate(5),
+		WithOnComplete(func(r CookResult) { results = append(results, r) }),
+		recordRejections(&got),
+	)
+
+	done := make(chan struct{})
+	go func() {
+		m.StartToTemp(context.Background(), 35)
+		close(done)
+	}()
+
+	// The display shows the temperature while heating
+	clock.Tick(t, 1)
+	clock.BlockUntil(t, 1)
+	if got := m.Display(); got != "25C" {
+		t.Errorf("Display() = %s one second in, want 25C", got)
+	}
+	clock.Tick(t, 2)
+	<-done
+
+	if got := out.String(); got != "20C\r\n25C\r\n30C\r\n35C\r\n" {
+		t.Errorf("frames = %q, want 20C up to 35C", got)
+	}
+	if len(results) != 1 || !results[0].Completed || results[0].ElapsedSeconds != 3 {
+		t.Errorf("results = %+v, want one completed 3 second cook", results)
+	}
+	if !strings.Contains(buf.String(), "cooking to temperature") {
+		t.Error("expected 'cooking to temperature' in logs")
+	}
+	if got := m.Display(); got != "00:00" {
+		t.Errorf("Display() = %s after the cook, want 00:00", got)
+	}
+
+	// The food can't be cooked to where it already is
+	m.StartToTemp(context.Background(), ambientC)
+	if len(got) != 1 || got[0].reason != TargetTooLow {
+		t.Errorf("rejections = %v, want one TargetTooLow", got)
+	}
+}
+
+// TestIntegrationPostCookInputDelay verifies that presses right after a cook ends are ignored.
+// Test logic: Cooks 00:02 with a 500ms post-cook delay on a fake clock, presses 5 right after it
+// completes and checks the display stays 00:00 with the press logged at debug. After the window,
+// checks presses are entered again. Then cancels a cook and checks the window applies too.
+func TestIntegrationPostCookInputDelay(t *testing.T) {
+	var buf bytes.Buffer
+	clock := newFakeClock()
+	m := New(
+		WithLogger(slog.New(slog.NewJSONHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug}))),
+		WithClock(clock),
+		WithOutput(io.Discard),
+		WithPostCookInputDelay(500*time.Millisecond),
+	)
+
+	m.SetDuration(2 * time.Second)
+	done := make(chan struct{})
+	go func() {
+		m.PressStart(context.Background())
+		close(done)
+	}()
+	clock.Tick(t, 2)
+	<-done
+
+	// A press straight after the cook is dropped
+	m.PressDigit(5)
+	if got := m.Display(); got != "00:00" {
+		t.Errorf("Display() = %s after a press within the delay, want 00:00", got)
+	}
+	if !strings.Contains(buf.String(), `"level":"DEBUG","msg":"press ignored just after cook"`) {
+		t.Error("expected 'press ignored just after cook' at debug in logs")
+	}
+
+	// Once the window passes, input works as usual
+	clock.Advance(500 * time.Millisecond)
+	m.PressDigit(5)
+	if got := m.Display(); got != "00:05" {
+		t.Errorf("Display() = %s after the delay, want 00:05", got)
+	}
+
+	// Canceled cooks get the same window
+	done = make(chan struct{})
+	go func() {
+		m.PressStart(context.Background())
+		close(done)
+	}()
+	clock.BlockUntil(t, 1)
+	m.CancelCook()
+	<-done
+	m.PressBackspace()
+	m.PressDigit(7)
+	if got := m.Display(); got != "00:00" {
+		t.Errorf("Display() = %s after presses within the delay of a canceled cook, want 00:00", got)
+	}
+}
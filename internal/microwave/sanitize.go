@@ -0,0 +1,80 @@
+package microwave
+
+import (
+	"context"
+	"log/slog"
+	"strings"
+	"unicode"
+)
+
+// NewLogSanitizer returns a sanitizer for WithLogSanitizer that replaces
+// control characters such as newlines with spaces, so a value can't forge
+// extra log lines, and truncates values longer than maxLen runes with "...".
+// A non-positive maxLen disables truncation.
+func NewLogSanitizer(maxLen int) func(string) string {
+	return func(s string) string {
+		s = strings.Map(func(r rune) rune {
+			if unicode.IsControl(r) {
+				return ' '
+			}
+			return r
+		}, s)
+		if maxLen > 0 {
+			if runes := []rune(s); len(runes) > maxLen {
+				s = string(runes[:maxLen]) + "..."
+			}
+		}
+		return s
+	}
+}
+
+// sanitizingHandler runs the string attribute values of every record,
+// including those added with Logger.With and inside groups, through a
+// sanitizer before passing the record on
+type sanitizingHandler struct {
+	next     slog.Handler
+	sanitize func(string) string
+}
+
+func (h *sanitizingHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	return h.next.Enabled(ctx, level)
+}
+
+func (h *sanitizingHandler) Handle(ctx context.Context, r slog.Record) error {
+	sanitized := slog.NewRecord(r.Time, r.Level, r.Message, r.PC)
+	r.Attrs(func(a slog.Attr) bool {
+		sanitized.AddAttrs(h.attr(a))
+		return true
+	})
+	return h.next.Handle(ctx, sanitized)
+}
+
+func (h *sanitizingHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	sanitized := make([]slog.Attr, len(attrs))
+	for i, a := range attrs {
+		sanitized[i] = h.attr(a)
+	}
+	return &sanitizingHandler{next: h.next.WithAttrs(sanitized), sanitize: h.sanitize}
+}
+
+func (h *sanitizingHandler) WithGroup(name string) slog.Handler {
+	return &sanitizingHandler{next: h.next.WithGroup(name), sanitize: h.sanitize}
+}
+
+// attr sanitizes a string attribute, or the string attributes in a group
+func (h *sanitizingHandler) attr(a slog.Attr) slog.Attr {
+	a.Value = a.Value.Resolve()
+	switch a.Value.Kind() {
+	case slog.KindString:
+		return slog.String(a.Key, h.sanitize(a.Value.String()))
+	case slog.KindGroup:
+		group := a.Value.Group()
+		sanitized := make([]slog.Attr, len(group))
+		for i, ga := range group {
+			sanitized[i] = h.attr(ga)
+		}
+		return slog.Attr{Key: a.Key, Value: slog.GroupValue(sanitized...)}
+	default:
+		return a
+	}
+}
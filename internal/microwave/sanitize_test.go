@@ -0,0 +1,83 @@
+package microwave
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"strings"
+	"testing"
+)
+
+// NewLogSanitizer Test Cases
+
+// TestNewLogSanitizer verifies that control characters are replaced and long values truncated.
+// Test logic: Uses table-driven tests to sanitize values with newlines, tabs, and more runes
+// than the limit, and checks each against the expected output.
+func TestNewLogSanitizer(t *testing.T) {
+	sanitize := NewLogSanitizer(8)
+	tests := []struct {
+		name     string
+		value    string
+		expected string
+	}{
+		{"unchanged", "popcorn", "popcorn"},
+		{"newlines", "a\nb\r\n", "a b  "},
+		{"tab and escape", "a\tb\x1b", "a b "},
+		{"truncated by rune", "ééééééééé", "éééééééé..."},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := sanitize(tt.value); got != tt.expected {
+				t.Errorf("sanitize(%q) = %q, want %q", tt.value, got, tt.expected)
+			}
+		})
+	}
+}
+
+// WithLogSanitizer Test Cases
+
+// TestWithLogSanitizer verifies that string log attributes are sanitized, wherever they were added.
+// Test logic: Builds a microwave with a JSON logger and a sanitizer, logs a value with embedded
+// newlines directly, through Logger.With, and inside a group, and checks every value had its
+// newlines replaced while the message and a non-string attribute were left alone.
+func TestWithLogSanitizer(t *testing.T) {
+	var buf bytes.Buffer
+	m := New(
+		WithLogSanitizer(NewLogSanitizer(0)),
+		WithLogger(slog.New(slog.NewJSONHandler(&buf, nil))),
+	)
+
+	forged := "popcorn\n{\"level\":\"ERROR\",\"msg\":\"forged\"}"
+	m.Logger().With("preset", forged).Info("logged\nas is",
+		"name", forged,
+		slog.Group("request", "note", forged),
+		"count", 3,
+	)
+
+	var entry struct {
+		Msg     string `json:"msg"`
+		Preset  string `json:"preset"`
+		Name    string `json:"name"`
+		Count   int    `json:"count"`
+		Request struct {
+			Note string `json:"note"`
+		} `json:"request"`
+	}
+	if err := json.Unmarshal(buf.Bytes(), &entry); err != nil {
+		t.Fatalf("invalid log line %q: %v", buf.String(), err)
+	}
+
+	want := strings.ReplaceAll(forged, "\n", " ")
+	for name, got := range map[string]string{"With": entry.Preset, "attribute": entry.Name, "group": entry.Request.Note} {
+		if got != want {
+			t.Errorf("%s value = %q, want %q", name, got, want)
+		}
+	}
+	if entry.Msg != "logged\nas is" {
+		t.Errorf("msg = %q, want it unchanged", entry.Msg)
+	}
+	if entry.Count != 3 {
+		t.Errorf("count = %d, want 3", entry.Count)
+	}
+}
@@ -0,0 +1,56 @@
+package microwave
+
+// Speaker renders a display time as words, for screen readers and
+// text-to-speech. Implement it to announce times in another language.
+type Speaker interface {
+	// Speak returns the words for the minutes and seconds on the display.
+	// Seconds can be above 59, as on the keypad.
+	Speak(minutes, seconds int) string
+}
+
+// English speaks times in English, such as "one minute thirty-five
+// seconds". It's the default Speaker.
+type English struct{}
+
+var (
+	englishOnes = []string{
+		"zero", "one", "two", "three", "four", "five", "six", "seven", "eight", "nine",
+		"ten", "eleven", "twelve", "thirteen", "fourteen", "fifteen", "sixteen",
+		"seventeen", "eighteen", "nineteen",
+	}
+	englishTens = []string{
+		"", "", "twenty", "thirty", "forty", "fifty", "sixty", "seventy", "eighty", "ninety",
+	}
+)
+
+// Speak implements Speaker. Zero minutes are left out, and so are zero
+// seconds unless the whole time is zero.
+func (English) Speak(minutes, seconds int) string {
+	switch {
+	case minutes == 0:
+		return englishUnit(seconds, "second")
+	case seconds == 0:
+		return englishUnit(minutes, "minute")
+	default:
+		return englishUnit(minutes, "minute") + " " + englishUnit(seconds, "second")
+	}
+}
+
+// englishUnit speaks n (0-99) followed by unit, pluralized as needed
+func englishUnit(n int, unit string) string {
+	if n != 1 {
+		unit += "s"
+	}
+	return englishNumber(n) + " " + unit
+}
+
+// englishNumber speaks n (0-99), hyphenating compound numbers like thirty-five
+func englishNumber(n int) string {
+	if n < 20 {
+		return englishOnes[n]
+	}
+	if n%10 == 0 {
+		return englishTens[n/10]
+	}
+	return englishTens[n/10] + "-" + englishOnes[n%10]
+}
@@ -0,0 +1,84 @@
+package microwave
+
+import (
+	"fmt"
+	"io"
+	"testing"
+)
+
+// English Test Cases
+
+// TestEnglishSpeak verifies that English puts minutes and seconds into words.
+// Test logic: Uses table-driven tests covering singulars, plurals, teens, hyphenated compound
+// numbers, zero parts, and seconds above 59, and checks each against the expected words.
+func TestEnglishSpeak(t *testing.T) {
+	tests := []struct {
+		minutes  int
+		seconds  int
+		expected string
+	}{
+		{0, 0, "zero seconds"},
+		{0, 1, "one second"},
+		{0, 5, "five seconds"},
+		{0, 13, "thirteen seconds"},
+		{0, 75, "seventy-five seconds"},
+		{1, 0, "one minute"},
+		{1, 35, "one minute thirty-five seconds"},
+		{20, 1, "twenty minutes one second"},
+		{99, 99, "ninety-nine minutes ninety-nine seconds"},
+	}
+
+	for _, tt := range tests {
+		t.Run(fmt.Sprintf("%02d:%02d", tt.minutes, tt.seconds), func(t *testing.T) {
+			if got := (English{}).Speak(tt.minutes, tt.seconds); got != tt.expected {
+				t.Errorf("Speak(%d, %d) = %q, want %q", tt.minutes, tt.seconds, got, tt.expected)
+			}
+		})
+	}
+}
+
+// DisplaySpoken Test Cases
+
+// TestDisplaySpoken verifies that DisplaySpoken announces the current display.
+// Test logic: Enters 1,3,5 and 5 on new microwaves and checks the spoken display is
+// "one minute thirty-five seconds" and "five seconds".
+func TestDisplaySpoken(t *testing.T) {
+	tests := []struct {
+		digits   []int
+		expected string
+	}{
+		{[]int{1, 3, 5}, "one minute thirty-five seconds"},
+		{[]int{5}, "five seconds"},
+	}
+
+	for _, tt := range tests {
+		m := New(WithOutput(io.Discard))
+		for _, d := range tt.digits {
+			m.PressDigit(d)
+		}
+		if got := m.DisplaySpoken(); got != tt.expected {
+			t.Errorf("DisplaySpoken() = %q for %s, want %q", got, m.Display(), tt.expected)
+		}
+	}
+}
+
+// shortSpeaker is a Speaker that announces times as "M min S sec"
+type shortSpeaker struct{}
+
+func (shortSpeaker) Speak(minutes, seconds int) string {
+	return fmt.Sprintf("%d min %d sec", minutes, seconds)
+}
+
+// TestDisplaySpokenWithSpeaker verifies that WithSpeaker replaces the English words.
+// Test logic: Enters 1,3,5 on a microwave with a custom Speaker and checks DisplaySpoken
+// returns the custom speaker's words.
+func TestDisplaySpokenWithSpeaker(t *testing.T) {
+	m := New(WithOutput(io.Discard), WithSpeaker(shortSpeaker{}))
+	for _, d := range []int{1, 3, 5} {
+		m.PressDigit(d)
+	}
+
+	if got := m.DisplaySpoken(); got != "1 min 35 sec" {
+		t.Errorf("DisplaySpoken() = %q, want %q", got, "1 min 35 sec")
+	}
+}
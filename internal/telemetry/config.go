@@ -1,10 +1,17 @@
 package telemetry
 
 import (
+	"errors"
 	"flag"
+	"fmt"
 	"log/slog"
+	"maps"
 	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
 	"strings"
+	"time"
 )
 
 // Environment determines which logging handler to use
@@ -18,10 +25,61 @@ const (
 
 // Config holds telemetry configuration
 type Config struct {
-	Environment  Environment
-	LogLevel     slog.Level
-	LogFile      string
-	OTLPEndpoint string
+	Environment        Environment
+	LogLevel           slog.Level
+	LogFile            string
+	OTLPEndpoint       string
+	OTLPEndpoints      []string          // Failover collectors, tried in order when OTLPEndpoint fails
+	OTLPLogEndpoint    string            // Where logs are exported, if not OTLPEndpoint
+	OTLPHeaders        map[string]string // Sent with every export, e.g. auth tokens
+	OTLPConnectTimeout time.Duration
+	OTLPRetry          RetryConfig
+	HistogramBuckets   []float64 // Bucket boundaries for every histogram (nil keeps the SDK defaults)
+
+	// OfflineBuffer is a directory where exports are buffered while the
+	// collector is unreachable, to be sent once it recovers ("" disables)
+	OfflineBuffer         string
+	OfflineBufferMaxBytes int64 // Per signal; 0 means 64 MiB
+}
+
+// redacted replaces secret values when the config is logged
+const redacted = "REDACTED"
+
+// LogValue implements slog.LogValuer. OTLP header values are redacted
+// since they commonly carry credentials.
+func (c Config) LogValue() slog.Value {
+	headers := make([]slog.Attr, 0, len(c.OTLPHeaders))
+	for _, name := range slices.Sorted(maps.Keys(c.OTLPHeaders)) {
+		headers = append(headers, slog.String(name, redacted))
+	}
+
+	return slog.GroupValue(
+		slog.String("environment", string(c.Environment)),
+		slog.String("log_level", c.LogLevel.String()),
+		slog.String("log_file", c.LogFile),
+		slog.String("otlp_endpoint", c.OTLPEndpoint),
+		slog.Any("otlp_endpoints", c.OTLPEndpoints),
+		slog.String("otlp_log_endpoint", c.OTLPLogEndpoint),
+		slog.Attr{Key: "otlp_headers", Value: slog.GroupValue(headers...)},
+		slog.Duration("otlp_timeout", c.OTLPConnectTimeout),
+		slog.Group("otlp_retry",
+			slog.Bool("enabled", c.OTLPRetry.Enabled),
+			slog.Duration("initial_interval", c.OTLPRetry.InitialInterval),
+			slog.Duration("max_interval", c.OTLPRetry.MaxInterval),
+			slog.Duration("max_elapsed", c.OTLPRetry.MaxElapsedTime),
+		),
+		slog.Any("histogram_buckets", c.HistogramBuckets),
+		slog.String("offline_buffer", c.OfflineBuffer),
+	)
+}
+
+// RetryConfig controls how the OTLP exporters retry failed exports.
+// Fields mirror the exporters' own RetryConfig so it converts directly.
+type RetryConfig struct {
+	Enabled         bool
+	InitialInterval time.Duration
+	MaxInterval     time.Duration
+	MaxElapsedTime  time.Duration
 }
 
 // envOrDefault returns the env var value or a default
@@ -32,52 +90,301 @@ func envOrDefault(key, defaultVal string) string {
 	return defaultVal
 }
 
+// durationEnvOrDefault returns the env var parsed as a duration, or a default
+// if the variable is unset. An invalid value returns the default and an error.
+func durationEnvOrDefault(key string, defaultVal time.Duration) (time.Duration, error) {
+	v := os.Getenv(key)
+	if v == "" {
+		return defaultVal, nil
+	}
+	d, err := time.ParseDuration(v)
+	if err != nil {
+		return defaultVal, fmt.Errorf("invalid %s %q: expected a duration such as 10s", key, v)
+	}
+	return d, nil
+}
+
+// boolEnvOrDefault returns the env var parsed as a bool, or a default
+// if the variable is unset. An invalid value returns the default and an error.
+func boolEnvOrDefault(key string, defaultVal bool) (bool, error) {
+	v := os.Getenv(key)
+	if v == "" {
+		return defaultVal, nil
+	}
+	b, err := strconv.ParseBool(v)
+	if err != nil {
+		return defaultVal, fmt.Errorf("invalid %s %q: expected true or false", key, v)
+	}
+	return b, nil
+}
+
 // ParseConfig reads configuration from flags and environment variables.
 // Flags take precedence over environment variables.
 // All env vars use the MEGAWAVE_ prefix.
-func ParseConfig() Config {
+//
+// Every invalid value is reported together in the returned error, joined
+// with errors.Join, so they can all be fixed in one pass. The Config is
+// still filled in, with defaults in place of the invalid values.
+func ParseConfig() (Config, error) {
+	return parseConfig(flag.CommandLine, os.Args[1:])
+}
+
+// ParseConfigArgs is ParseConfig for a flag set other than the command
+// line's, such as a subcommand's. It defines the config flags on fs
+// alongside any the caller defined, then parses args.
+func ParseConfigArgs(fs *flag.FlagSet, args []string) (Config, error) {
+	return parseConfig(fs, args)
+}
+
+// parseConfig defines the config flags on fs and parses args
+func parseConfig(fs *flag.FlagSet, args []string) (Config, error) {
+	var errs []error
+
+	// Load the env file first so its values become the flag defaults below.
+	// A missing default .env is fine; a missing explicit -env-file is not.
+	if path := envFileArg(args); path != "" {
+		if err := LoadEnvFile(path); err != nil {
+			errs = append(errs, fmt.Errorf("failed to load env file: %w", err))
+		}
+	} else if err := LoadEnvFile(defaultEnvFile); err != nil && !errors.Is(err, os.ErrNotExist) {
+		errs = append(errs, fmt.Errorf("failed to load env file: %w", err))
+	}
+	fs.String("env-file", defaultEnvFile,
+		"load environment variables from this file (real env vars take precedence)")
+
+	// Env values that don't parse, by the flag they default. They are
+	// reported after parsing unless that flag overrides them.
+	envErrs := make(map[string]error)
+	durationEnv := func(name, key string, defaultVal time.Duration) time.Duration {
+		d, err := durationEnvOrDefault(key, defaultVal)
+		if err != nil {
+			envErrs[name] = err
+		}
+		return d
+	}
+	boolEnv := func(name, key string, defaultVal bool) bool {
+		b, err := boolEnvOrDefault(key, defaultVal)
+		if err != nil {
+			envErrs[name] = err
+		}
+		return b
+	}
+
 	// Define flags with env var defaults
-	envFlag := flag.String("env", envOrDefault("MEGAWAVE_ENV", "development"),
+	envFlag := fs.String("env", envOrDefault("MEGAWAVE_ENV", "development"),
 		"environment: production, development, test")
-	logLevelFlag := flag.String("log-level", envOrDefault("MEGAWAVE_LOG_LEVEL", "info"),
+	logLevelFlag := fs.String("log-level", envOrDefault("MEGAWAVE_LOG_LEVEL", "info"),
 		"log level: debug, info, warn, error")
-	logFileFlag := flag.String("log-file", envOrDefault("MEGAWAVE_LOG_FILE", "megawave.log"),
+	logFileFlag := fs.String("log-file", envOrDefault("MEGAWAVE_LOG_FILE", "megawave.log"),
 		"log file path (development mode only)")
-	otlpFlag := flag.String("otlp-endpoint", os.Getenv("MEGAWAVE_OTLP_ENDPOINT"),
+	otlpFlag := fs.String("otlp-endpoint", os.Getenv("MEGAWAVE_OTLP_ENDPOINT"),
 		"OTLP collector endpoint (host:port, e.g., localhost:4318)")
+	otlpFailoverFlag := fs.String("otlp-endpoints", os.Getenv("MEGAWAVE_OTLP_ENDPOINTS"),
+		"comma-separated failover OTLP endpoints, tried in order when -otlp-endpoint fails")
+	otlpLogFlag := fs.String("otlp-log-endpoint", os.Getenv("MEGAWAVE_OTLP_LOG_ENDPOINT"),
+		"OTLP collector endpoint for logs only (defaults to -otlp-endpoint)")
+	otlpHeadersFlag := fs.String("otlp-headers", os.Getenv("MEGAWAVE_OTLP_HEADERS"),
+		"OTLP headers as comma-separated key=value pairs")
+	otlpTimeoutFlag := fs.Duration("otlp-timeout", durationEnv("otlp-timeout", "MEGAWAVE_OTLP_TIMEOUT", 10*time.Second),
+		"timeout for each OTLP export request")
+	otlpRetryFlag := fs.Bool("otlp-retry", boolEnv("otlp-retry", "MEGAWAVE_OTLP_RETRY", true),
+		"retry failed OTLP exports")
+	otlpRetryMaxFlag := fs.Duration("otlp-retry-max-elapsed", durationEnv("otlp-retry-max-elapsed", "MEGAWAVE_OTLP_RETRY_MAX_ELAPSED", time.Minute),
+		"maximum time spent retrying a failed OTLP export")
+	otlpRetryInitialFlag := fs.Duration("otlp-retry-initial-interval", durationEnv("otlp-retry-initial-interval", "MEGAWAVE_OTLP_RETRY_INITIAL_INTERVAL", 5*time.Second),
+		"wait before the first retry of a failed OTLP export")
+	otlpRetryIntervalFlag := fs.Duration("otlp-retry-max-interval", durationEnv("otlp-retry-max-interval", "MEGAWAVE_OTLP_RETRY_MAX_INTERVAL", 30*time.Second),
+		"longest wait between retries of a failed OTLP export")
+
+	offlineFlag := fs.String("offline-buffer", os.Getenv("MEGAWAVE_OFFLINE_BUFFER"),
+		"directory to buffer telemetry in while the OTLP collector is unreachable")
+	bucketsFlag := fs.String("histogram-buckets", os.Getenv("MEGAWAVE_HISTOGRAM_BUCKETS"),
+		"histogram bucket boundaries as increasing comma-separated numbers (default SDK buckets)")
+
+	// Parse errors exit the program for flag.CommandLine
+	if err := fs.Parse(args); err != nil {
+		errs = append(errs, err)
+	}
+	fs.Visit(func(f *flag.Flag) { delete(envErrs, f.Name) })
+	for _, name := range slices.Sorted(maps.Keys(envErrs)) {
+		errs = append(errs, envErrs[name])
+	}
 
-	flag.Parse()
+	env, err := parseEnvironment(*envFlag)
+	if err != nil {
+		errs = append(errs, err)
+	}
+	level, err := parseLogLevel(*logLevelFlag)
+	if err != nil {
+		errs = append(errs, err)
+	}
 
-	return Config{
-		Environment:  parseEnvironment(*envFlag),
-		LogLevel:     parseLogLevel(*logLevelFlag),
-		LogFile:      *logFileFlag,
-		OTLPEndpoint: *otlpFlag,
+	buckets, err := parseBuckets(*bucketsFlag)
+	if err != nil {
+		errs = append(errs, err)
 	}
+
+	cfg := Config{
+		Environment:        env,
+		LogLevel:           level,
+		LogFile:            *logFileFlag,
+		OTLPEndpoint:       *otlpFlag,
+		OTLPEndpoints:      parseList(*otlpFailoverFlag),
+		OTLPLogEndpoint:    *otlpLogFlag,
+		OTLPHeaders:        parseHeaders(*otlpHeadersFlag),
+		OTLPConnectTimeout: *otlpTimeoutFlag,
+		OTLPRetry: RetryConfig{
+			Enabled:         *otlpRetryFlag,
+			InitialInterval: *otlpRetryInitialFlag,
+			MaxInterval:     *otlpRetryIntervalFlag,
+			MaxElapsedTime:  *otlpRetryMaxFlag,
+		},
+		HistogramBuckets: buckets,
+		OfflineBuffer:    *offlineFlag,
+	}
+
+	if err := checkRetry(cfg.OTLPRetry); err != nil {
+		errs = append(errs, err)
+	}
+	if cfg.Environment == Development {
+		if err := checkLogFile(cfg.LogFile); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if cfg.OTLPEndpoint != "" || cfg.OTLPLogEndpoint != "" {
+		if _, err := resolveEndpoints(cfg); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if _, err := resolveFailoverEndpoints(cfg); err != nil {
+		errs = append(errs, err)
+	}
+
+	return cfg, errors.Join(errs...)
 }
 
-// parseEnvironment converts a string to an Environment value
-func parseEnvironment(s string) Environment {
+// checkRetry reports retry intervals the exporters can't back off between:
+// non-positive ones, or an initial interval longer than the maximum
+func checkRetry(r RetryConfig) error {
+	if r.InitialInterval <= 0 || r.MaxInterval <= 0 {
+		return fmt.Errorf("invalid OTLP retry intervals %s and %s: must be positive", r.InitialInterval, r.MaxInterval)
+	}
+	if r.InitialInterval > r.MaxInterval {
+		return fmt.Errorf("invalid OTLP retry initial interval %s: longer than the max interval %s", r.InitialInterval, r.MaxInterval)
+	}
+	return nil
+}
+
+// parseEnvironment converts a string to an Environment value.
+// Unknown values return Development and an error.
+func parseEnvironment(s string) (Environment, error) {
 	switch strings.ToLower(s) {
 	case "production", "prod":
-		return Production
+		return Production, nil
 	case "test":
-		return Test
+		return Test, nil
+	case "development", "dev":
+		return Development, nil
 	default:
-		return Development
+		return Development, fmt.Errorf("invalid environment %q: expected production, development or test", s)
 	}
 }
 
-// parseLogLevel converts a string to a slog.Level
-func parseLogLevel(s string) slog.Level {
+// parseLogLevel converts a string to a slog.Level.
+// Unknown values return slog.LevelInfo and an error.
+func parseLogLevel(s string) (slog.Level, error) {
 	switch strings.ToLower(s) {
 	case "debug":
-		return slog.LevelDebug
+		return slog.LevelDebug, nil
+	case "info":
+		return slog.LevelInfo, nil
 	case "warn", "warning":
-		return slog.LevelWarn
+		return slog.LevelWarn, nil
 	case "error":
-		return slog.LevelError
+		return slog.LevelError, nil
 	default:
-		return slog.LevelInfo
+		return slog.LevelInfo, fmt.Errorf("invalid log level %q: expected debug, info, warn or error", s)
+	}
+}
+
+// checkLogFile reports whether the development log file can be opened for
+// append. A file that doesn't exist yet is created to check, then removed,
+// so checking leaves nothing behind.
+func checkLogFile(path string) error {
+	if info, err := os.Stat(path); err == nil {
+		if info.IsDir() {
+			return fmt.Errorf("invalid log file %q: is a directory", path)
+		}
+		f, err := os.OpenFile(path, os.O_WRONLY|os.O_APPEND, 0)
+		if err != nil {
+			return fmt.Errorf("invalid log file %q: %w", path, err)
+		}
+		return f.Close()
+	}
+
+	dir := filepath.Dir(path)
+	info, err := os.Stat(dir)
+	if err != nil {
+		return fmt.Errorf("invalid log file %q: %w", path, err)
+	}
+	if !info.IsDir() {
+		return fmt.Errorf("invalid log file %q: %s is not a directory", path, dir)
+	}
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_APPEND|os.O_CREATE|os.O_EXCL, 0644)
+	if err != nil {
+		return fmt.Errorf("invalid log file %q: %w", path, err)
+	}
+	f.Close()
+	if err := os.Remove(path); err != nil {
+		return fmt.Errorf("invalid log file %q: %w", path, err)
+	}
+	return nil
+}
+
+// parseBuckets converts "1,5,10" into histogram bucket boundaries, which
+// must be strictly increasing. An empty string returns nil.
+func parseBuckets(s string) ([]float64, error) {
+	if strings.TrimSpace(s) == "" {
+		return nil, nil
+	}
+
+	var buckets []float64
+	for _, field := range strings.Split(s, ",") {
+		b, err := strconv.ParseFloat(strings.TrimSpace(field), 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid histogram buckets %q: %q is not a number", s, field)
+		}
+		if len(buckets) > 0 && b <= buckets[len(buckets)-1] {
+			return nil, fmt.Errorf("invalid histogram buckets %q: boundaries must be increasing", s)
+		}
+		buckets = append(buckets, b)
+	}
+	return buckets, nil
+}
+
+// parseList splits a comma-separated list, trimming spaces and dropping
+// empty entries. An empty string returns nil.
+func parseList(s string) []string {
+	var list []string
+	for _, field := range strings.Split(s, ",") {
+		if field = strings.TrimSpace(field); field != "" {
+			list = append(list, field)
+		}
+	}
+	return list
+}
+
+// parseHeaders converts "key1=value1,key2=value2" into a map.
+// Entries without an "=" are ignored.
+func parseHeaders(s string) map[string]string {
+	headers := make(map[string]string)
+	for _, pair := range strings.Split(s, ",") {
+		name, value, ok := strings.Cut(pair, "=")
+		name = strings.TrimSpace(name)
+		if !ok || name == "" {
+			continue
+		}
+		headers[name] = strings.TrimSpace(value)
 	}
+	return headers
 }
@@ -0,0 +1,334 @@
+package telemetry
+
+import (
+	"bytes"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+)
+
+// newTestFlagSet returns a flag set that reports errors instead of exiting
+func newTestFlagSet() *flag.FlagSet {
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+	return fs
+}
+
+// mustParseConfig parses args with a fresh flag set, failing the test on error
+func mustParseConfig(t *testing.T, args []string) Config {
+	t.Helper()
+
+	cfg, err := parseConfig(newTestFlagSet(), args)
+	if err != nil {
+		t.Fatalf("parseConfig(%q) error = %v", args, err)
+	}
+	return cfg
+}
+
+// parseConfig Test Cases
+
+// TestParseConfigOTLPRetryDefaults verifies the OTLP timeout and retry defaults.
+// Test logic: Parses an empty argument list and checks retry is enabled with the
+// default timeout, intervals and max elapsed time.
+func TestParseConfigOTLPRetryDefaults(t *testing.T) {
+	cfg := mustParseConfig(t, nil)
+
+	if cfg.OTLPConnectTimeout != 10*time.Second {
+		t.Errorf("OTLPConnectTimeout = %v, want 10s", cfg.OTLPConnectTimeout)
+	}
+	if !cfg.OTLPRetry.Enabled {
+		t.Error("expected OTLPRetry.Enabled to default to true")
+	}
+	if cfg.OTLPRetry.MaxElapsedTime != time.Minute {
+		t.Errorf("OTLPRetry.MaxElapsedTime = %v, want 1m", cfg.OTLPRetry.MaxElapsedTime)
+	}
+	if cfg.OTLPRetry.InitialInterval != 5*time.Second || cfg.OTLPRetry.MaxInterval != 30*time.Second {
+		t.Errorf("OTLPRetry intervals = %v, %v, want 5s, 30s", cfg.OTLPRetry.InitialInterval, cfg.OTLPRetry.MaxInterval)
+	}
+}
+
+// TestParseConfigOTLPRetryFlags verifies that the OTLP timeout and retry flags are parsed.
+// Test logic: Parses flags that change the timeout, disable retry, and set the intervals and
+// max elapsed time, then checks each value in the resulting Config.
+func TestParseConfigOTLPRetryFlags(t *testing.T) {
+	cfg := mustParseConfig(t, []string{
+		"-otlp-timeout=3s",
+		"-otlp-retry=false",
+		"-otlp-retry-max-elapsed=20s",
+		"-otlp-retry-initial-interval=1s",
+		"-otlp-retry-max-interval=4s",
+	})
+
+	if cfg.OTLPConnectTimeout != 3*time.Second {
+		t.Errorf("OTLPConnectTimeout = %v, want 3s", cfg.OTLPConnectTimeout)
+	}
+	if cfg.OTLPRetry.Enabled {
+		t.Error("expected OTLPRetry.Enabled to be false")
+	}
+	if cfg.OTLPRetry.MaxElapsedTime != 20*time.Second {
+		t.Errorf("OTLPRetry.MaxElapsedTime = %v, want 20s", cfg.OTLPRetry.MaxElapsedTime)
+	}
+	if cfg.OTLPRetry.InitialInterval != time.Second || cfg.OTLPRetry.MaxInterval != 4*time.Second {
+		t.Errorf("OTLPRetry intervals = %v, %v, want 1s, 4s", cfg.OTLPRetry.InitialInterval, cfg.OTLPRetry.MaxInterval)
+	}
+}
+
+// TestParseConfigOTLPRetryEnv verifies that the OTLP timeout and retry env vars are read
+// and that flags take precedence over them.
+// Test logic: Sets the env vars, parses with one overriding flag, and checks the env
+// values are used except where the flag overrides.
+func TestParseConfigOTLPRetryEnv(t *testing.T) {
+	t.Setenv("MEGAWAVE_OTLP_TIMEOUT", "7s")
+	t.Setenv("MEGAWAVE_OTLP_RETRY", "false")
+	t.Setenv("MEGAWAVE_OTLP_RETRY_MAX_ELAPSED", "2m")
+	t.Setenv("MEGAWAVE_OTLP_RETRY_INITIAL_INTERVAL", "2s")
+	t.Setenv("MEGAWAVE_OTLP_RETRY_MAX_INTERVAL", "8s")
+
+	cfg := mustParseConfig(t, []string{"-otlp-retry-max-elapsed=30s"})
+
+	if cfg.OTLPConnectTimeout != 7*time.Second {
+		t.Errorf("OTLPConnectTimeout = %v, want 7s", cfg.OTLPConnectTimeout)
+	}
+	if cfg.OTLPRetry.Enabled {
+		t.Error("expected OTLPRetry.Enabled to be false from env")
+	}
+	if cfg.OTLPRetry.MaxElapsedTime != 30*time.Second {
+		t.Errorf("OTLPRetry.MaxElapsedTime = %v, want 30s (flag overrides env)", cfg.OTLPRetry.MaxElapsedTime)
+	}
+	if cfg.OTLPRetry.InitialInterval != 2*time.Second || cfg.OTLPRetry.MaxInterval != 8*time.Second {
+		t.Errorf("OTLPRetry intervals = %v, %v, want 2s, 8s from env", cfg.OTLPRetry.InitialInterval, cfg.OTLPRetry.MaxInterval)
+	}
+}
+
+// TestParseConfigOTLPRetryIntervalsInvalid verifies that unusable retry intervals are reported.
+// Test logic: Uses table-driven tests to parse a zero interval and an initial interval
+// longer than the max, and checks each returns an error mentioning the retry interval.
+func TestParseConfigOTLPRetryIntervalsInvalid(t *testing.T) {
+	tests := []struct {
+		name string
+		args []string
+	}{
+		{"zero initial", []string{"-otlp-retry-initial-interval=0s"}},
+		{"initial above max", []string{"-otlp-retry-initial-interval=1m", "-otlp-retry-max-interval=10s"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := parseConfig(flag.NewFlagSet("test", flag.ContinueOnError), tt.args)
+			if err == nil || !strings.Contains(err.Error(), "retry") {
+				t.Errorf("parseConfig(%q) error = %v, want a retry interval error", tt.args, err)
+			}
+		})
+	}
+}
+
+// TestParseConfigOTLPRetryEnvInvalid verifies that unparsable timeout and retry env vars are reported.
+// Test logic: Sets each env var to a value that doesn't parse and checks the error names it
+// and the default is used, then checks a flag overriding the env var clears the error.
+func TestParseConfigOTLPRetryEnvInvalid(t *testing.T) {
+	for _, key := range []string{
+		"MEGAWAVE_OTLP_TIMEOUT",
+		"MEGAWAVE_OTLP_RETRY",
+		"MEGAWAVE_OTLP_RETRY_MAX_ELAPSED",
+		"MEGAWAVE_OTLP_RETRY_INITIAL_INTERVAL",
+		"MEGAWAVE_OTLP_RETRY_MAX_INTERVAL",
+	} {
+		t.Run(key, func(t *testing.T) {
+			t.Setenv(key, "abc")
+			_, err := parseConfig(newTestFlagSet(), nil)
+			if want := fmt.Sprintf(`invalid %s "abc"`, key); err == nil || !strings.Contains(err.Error(), want) {
+				t.Errorf("parseConfig() error = %v, want it to mention %s", err, want)
+			}
+		})
+	}
+
+	t.Setenv("MEGAWAVE_OTLP_TIMEOUT", "abc")
+	cfg, _ := parseConfig(newTestFlagSet(), nil)
+	if cfg.OTLPConnectTimeout != 10*time.Second {
+		t.Errorf("OTLPConnectTimeout = %v, want the 10s default for an invalid value", cfg.OTLPConnectTimeout)
+	}
+
+	// The flag takes precedence, so the env var no longer matters
+	cfg, err := parseConfig(newTestFlagSet(), []string{"-otlp-timeout=3s"})
+	if err != nil {
+		t.Errorf("parseConfig(-otlp-timeout=3s) error = %v, want nil", err)
+	}
+	if cfg.OTLPConnectTimeout != 3*time.Second {
+		t.Errorf("OTLPConnectTimeout = %v, want 3s from the flag", cfg.OTLPConnectTimeout)
+	}
+}
+
+// TestParseConfigOTLPHeaders verifies that OTLP headers are parsed from key=value pairs.
+// Test logic: Parses a headers flag with two pairs, surrounding spaces, and a malformed
+// entry, and checks the resulting map.
+func TestParseConfigOTLPHeaders(t *testing.T) {
+	cfg := mustParseConfig(t, []string{"-otlp-headers=Authorization=Bearer abc, X-Team = ops,junk"})
+
+	if len(cfg.OTLPHeaders) != 2 {
+		t.Fatalf("OTLPHeaders = %v, want 2 entries", cfg.OTLPHeaders)
+	}
+	if got := cfg.OTLPHeaders["Authorization"]; got != "Bearer abc" {
+		t.Errorf("Authorization header = %q, want %q", got, "Bearer abc")
+	}
+	if got := cfg.OTLPHeaders["X-Team"]; got != "ops" {
+		t.Errorf("X-Team header = %q, want %q", got, "ops")
+	}
+}
+
+// TestParseConfigOTLPEndpoints verifies that failover endpoints are parsed and need a primary.
+// Test logic: Parses a primary with a comma-separated failover list containing spaces and an
+// empty entry and checks the list, then checks failover endpoints without a primary, or with
+// an invalid entry, are reported.
+func TestParseConfigOTLPEndpoints(t *testing.T) {
+	cfg := mustParseConfig(t, []string{
+		"-otlp-endpoint=localhost:4318",
+		"-otlp-endpoints=backup-1:4318, ,http://backup-2:4318",
+	})
+	if want := []string{"backup-1:4318", "http://backup-2:4318"}; !slices.Equal(cfg.OTLPEndpoints, want) {
+		t.Errorf("OTLPEndpoints = %v, want %v", cfg.OTLPEndpoints, want)
+	}
+
+	for _, args := range [][]string{
+		{"-otlp-endpoints=backup:4318"},
+		{"-otlp-endpoint=localhost:4318", "-otlp-endpoints=backup"},
+	} {
+		if _, err := parseConfig(newTestFlagSet(), args); err == nil || !strings.Contains(err.Error(), "backup") {
+			t.Errorf("parseConfig(%q) error = %v, want an error naming the failover endpoint", args, err)
+		}
+	}
+}
+
+// LogValue Test Cases
+
+// TestParseConfigReportsAllErrors verifies that every invalid value is reported at once.
+// Test logic: Parses an invalid environment, log level, log file and OTLP endpoint together
+// and checks the single returned error mentions each of them, and that the Config still
+// falls back to defaults for the invalid values.
+func TestParseConfigReportsAllErrors(t *testing.T) {
+	badLogFile := filepath.Join(t.TempDir(), "missing", "megawave.log")
+	cfg, err := parseConfig(newTestFlagSet(), []string{
+		"-env=dev", // valid, so the log file is checked
+		"-log-level=loud",
+		"-log-file=" + badLogFile,
+		"-otlp-endpoint=collector",
+	})
+	if err == nil {
+		t.Fatal("parseConfig() error = nil, want errors")
+	}
+
+	for _, want := range []string{`invalid log level "loud"`, badLogFile, `invalid OTLP endpoint "collector"`} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("error should mention %s:\n%v", want, err)
+		}
+	}
+	if cfg.LogLevel != slog.LevelInfo {
+		t.Errorf("LogLevel = %v, want the INFO default for an invalid level", cfg.LogLevel)
+	}
+
+	// An invalid environment is reported alongside the others
+	_, err = parseConfig(newTestFlagSet(), []string{"-env=staging", "-log-level=loud"})
+	for _, want := range []string{`invalid environment "staging"`, `invalid log level "loud"`} {
+		if err == nil || !strings.Contains(err.Error(), want) {
+			t.Errorf("error should mention %s: %v", want, err)
+		}
+	}
+}
+
+// TestCheckLogFile verifies that the log file must be writable for append.
+// Test logic: Checks a new file in an existing directory passes without being left behind,
+// an existing file passes and keeps its contents, and a directory, a file in a missing
+// directory and a read-only file are each reported.
+func TestCheckLogFile(t *testing.T) {
+	dir := t.TempDir()
+
+	// A new file is created to check, then removed
+	path := filepath.Join(dir, "new.log")
+	if err := checkLogFile(path); err != nil {
+		t.Errorf("checkLogFile(new file) error = %v, want nil", err)
+	}
+	if _, err := os.Stat(path); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("checkLogFile left %s behind: %v", path, err)
+	}
+
+	// An existing file is opened without truncating it
+	path = filepath.Join(dir, "existing.log")
+	if err := os.WriteFile(path, []byte("kept\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := checkLogFile(path); err != nil {
+		t.Errorf("checkLogFile(existing file) error = %v, want nil", err)
+	}
+	if data, _ := os.ReadFile(path); string(data) != "kept\n" {
+		t.Errorf("existing log file contents = %q, want them kept", data)
+	}
+
+	for _, path := range []string{dir, filepath.Join(dir, "missing", "megawave.log")} {
+		if err := checkLogFile(path); err == nil || !strings.Contains(err.Error(), path) {
+			t.Errorf("checkLogFile(%s) error = %v, want an error naming it", path, err)
+		}
+	}
+
+	// Root can write to read-only files, so this case only runs unprivileged
+	if os.Geteuid() == 0 {
+		t.Log("running as root, skipping the read-only file")
+		return
+	}
+	path = filepath.Join(dir, "readonly.log")
+	if err := os.WriteFile(path, nil, 0444); err != nil {
+		t.Fatal(err)
+	}
+	if err := checkLogFile(path); err == nil {
+		t.Errorf("checkLogFile(read-only file) error = nil, want an error")
+	}
+}
+
+// TestParseConfigHistogramBuckets verifies that histogram buckets are parsed from a comma-separated list.
+// Test logic: Parses a list with spaces and checks the boundaries, then parses decreasing and
+// non-numeric lists and checks each returns an error naming the value.
+func TestParseConfigHistogramBuckets(t *testing.T) {
+	cfg := mustParseConfig(t, []string{"-histogram-buckets=1, 2.5,10"})
+	if want := []float64{1, 2.5, 10}; !slices.Equal(cfg.HistogramBuckets, want) {
+		t.Errorf("HistogramBuckets = %v, want %v", cfg.HistogramBuckets, want)
+	}
+
+	for _, buckets := range []string{"10,5", "1,ten"} {
+		_, err := parseConfig(newTestFlagSet(), []string{"-histogram-buckets=" + buckets})
+		if err == nil || !strings.Contains(err.Error(), buckets) {
+			t.Errorf("parseConfig(-histogram-buckets=%s) error = %v, want an error naming it", buckets, err)
+		}
+	}
+}
+
+// TestConfigLogValueRedactsHeaders verifies that logging a Config hides OTLP header values.
+// Test logic: Logs a Config with a secret header through a JSON handler and checks the
+// header name and REDACTED appear but the secret value does not.
+func TestConfigLogValueRedactsHeaders(t *testing.T) {
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewJSONHandler(&buf, nil))
+
+	cfg := Config{
+		Environment:  Production,
+		OTLPEndpoint: "localhost:4318",
+		OTLPHeaders:  map[string]string{"Authorization": "Bearer s3cret"},
+	}
+	logger.Info("configuration", "config", cfg)
+
+	logs := buf.String()
+	if strings.Contains(logs, "s3cret") {
+		t.Errorf("secret header value leaked into logs: %s", logs)
+	}
+	if !strings.Contains(logs, `"Authorization":"REDACTED"`) {
+		t.Errorf("expected redacted Authorization header in logs: %s", logs)
+	}
+	if !strings.Contains(logs, `"otlp_endpoint":"localhost:4318"`) {
+		t.Errorf("expected otlp_endpoint in logs: %s", logs)
+	}
+}
@@ -0,0 +1,131 @@
+package telemetry
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// unsetEnv unsets key for the rest of the test and restores it afterwards
+func unsetEnv(t *testing.T, key string) {
+	t.Helper()
+	t.Setenv(key, "")
+	_ = os.Unsetenv(key)
+}
+
+// writeEnvFile writes content to a .env file in a temp directory and returns its path
+func writeEnvFile(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), ".env")
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatalf("failed to write env file: %v", err)
+	}
+	return path
+}
+
+// parseDotenv Test Cases
+
+// TestParseDotenv verifies comments, export prefixes and quoting.
+// Test logic: Parses a file with blank lines, comments, an export prefix, unquoted values
+// with a trailing comment, and single and double quoted values, then checks each value.
+func TestParseDotenv(t *testing.T) {
+	content := `
+# comment line
+export PLAIN=value
+SPACED = padded value   # trailing comment
+DOUBLE="has # hash\nand newline"
+SINGLE='literal \n $x'
+EMPTY=
+`
+	vars, err := parseDotenv(strings.NewReader(content))
+	if err != nil {
+		t.Fatalf("parseDotenv() error = %v", err)
+	}
+
+	want := map[string]string{
+		"PLAIN":  "value",
+		"SPACED": "padded value",
+		"DOUBLE": "has # hash\nand newline",
+		"SINGLE": `literal \n $x`,
+		"EMPTY":  "",
+	}
+	if len(vars) != len(want) {
+		t.Errorf("got %d vars, want %d: %v", len(vars), len(want), vars)
+	}
+	for key, value := range want {
+		if vars[key] != value {
+			t.Errorf("%s = %q, want %q", key, vars[key], value)
+		}
+	}
+}
+
+// TestParseDotenvInvalid verifies that malformed lines are reported with their line number.
+// Test logic: Parses content missing an "=" and content with an unterminated quote and
+// checks each returns an error naming the line.
+func TestParseDotenvInvalid(t *testing.T) {
+	for _, content := range []string{"OK=1\nNOEQUALS", "# c\nQ=\"open"} {
+		_, err := parseDotenv(strings.NewReader(content))
+		if err == nil || !strings.Contains(err.Error(), "line 2") {
+			t.Errorf("parseDotenv(%q) error = %v, want a line 2 error", content, err)
+		}
+	}
+}
+
+// envFileArg Test Cases
+
+// TestEnvFileArg verifies that the -env-file value is found before the flags are parsed.
+// Test logic: Checks the "=value" and separate value forms with one or two dashes, that
+// other flags' values are skipped, and that arguments after "--" are ignored.
+func TestEnvFileArg(t *testing.T) {
+	tests := []struct {
+		args []string
+		want string
+	}{
+		{[]string{"-env-file=dev.env"}, "dev.env"},
+		{[]string{"--env-file", "dev.env"}, "dev.env"},
+		{[]string{"-otlp-endpoint", "localhost:4318", "-env-file", "dev.env"}, "dev.env"},
+		{[]string{"-log-level", "debug"}, ""},
+		{[]string{"--", "-env-file=dev.env"}, ""},
+	}
+	for _, tt := range tests {
+		if got := envFileArg(tt.args); got != tt.want {
+			t.Errorf("envFileArg(%v) = %q, want %q", tt.args, got, tt.want)
+		}
+	}
+}
+
+// LoadEnvFile Test Cases
+
+// TestParseConfigReadsEnvFile verifies that -env-file values are used when the real env is unset.
+// Test logic: Writes a .env setting the log level and OTLP endpoint, unsets both variables,
+// parses config with -env-file pointing at it, and checks the file's values were applied.
+func TestParseConfigReadsEnvFile(t *testing.T) {
+	unsetEnv(t, "MEGAWAVE_LOG_LEVEL")
+	unsetEnv(t, "MEGAWAVE_OTLP_ENDPOINT")
+	path := writeEnvFile(t, "# dev settings\nMEGAWAVE_LOG_LEVEL=debug\nMEGAWAVE_OTLP_ENDPOINT=\"collector:4318\"\n")
+
+	cfg := mustParseConfig(t, []string{"-env-file", path})
+
+	if cfg.LogLevel.String() != "DEBUG" {
+		t.Errorf("LogLevel = %v, want DEBUG from the env file", cfg.LogLevel)
+	}
+	if cfg.OTLPEndpoint != "collector:4318" {
+		t.Errorf("OTLPEndpoint = %q, want collector:4318 from the env file", cfg.OTLPEndpoint)
+	}
+}
+
+// TestLoadEnvFileRealEnvWins verifies that variables already in the environment are not overwritten.
+// Test logic: Sets MEGAWAVE_LOG_LEVEL in the real environment, loads a file that sets it to
+// something else, and checks the real value is kept.
+func TestLoadEnvFileRealEnvWins(t *testing.T) {
+	t.Setenv("MEGAWAVE_LOG_LEVEL", "warn")
+	path := writeEnvFile(t, "MEGAWAVE_LOG_LEVEL=debug\n")
+
+	if err := LoadEnvFile(path); err != nil {
+		t.Fatalf("LoadEnvFile() error = %v", err)
+	}
+	if got := os.Getenv("MEGAWAVE_LOG_LEVEL"); got != "warn" {
+		t.Errorf("MEGAWAVE_LOG_LEVEL = %q, want the real value warn", got)
+	}
+}
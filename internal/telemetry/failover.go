@@ -0,0 +1,56 @@
+package telemetry
+
+import (
+	"net/http"
+	"sync"
+)
+
+// failoverTransport is an http.RoundTripper for an OTLP exporter that sends
+// each request to one of several collectors. It starts with the endpoint
+// that took the last request and, when that one can't be reached or is
+// unavailable, tries the others in order. The first to take the request
+// becomes the endpoint used from then on, so exports stay on a working
+// secondary rather than returning to a failed primary every time.
+type failoverTransport struct {
+	next  http.RoundTripper
+	hosts []string // host:port of each collector, primary first
+
+	mu      sync.Mutex
+	current int // Index into hosts of the collector that took the last request
+}
+
+// RoundTrip sends the request to the current collector, failing over to the
+// others in turn. If none takes it, the last collector's response or error
+// is returned so the exporter can retry or report it.
+func (t *failoverTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	body, err := readBody(req)
+	if err != nil {
+		return nil, err
+	}
+
+	t.mu.Lock()
+	start := t.current
+	t.mu.Unlock()
+
+	var resp *http.Response
+	for i := range t.hosts {
+		if resp != nil {
+			// The previous collector refused it; move on to the next
+			discard(resp)
+		}
+
+		idx := (start + i) % len(t.hosts)
+		r := withBody(req, body)
+		r.URL.Host = t.hosts[idx]
+		r.Host = t.hosts[idx]
+
+		resp, err = t.next.RoundTrip(r)
+		if err == nil && !unavailable(resp.StatusCode) {
+			t.mu.Lock()
+			t.current = idx
+			t.mu.Unlock()
+			return resp, nil
+		}
+	}
+	return resp, err
+}
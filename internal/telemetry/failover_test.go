@@ -0,0 +1,97 @@
+package telemetry
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+// closedEndpoint returns the host:port of a collector that has shut down,
+// so connecting to it fails
+func closedEndpoint(t *testing.T) string {
+	t.Helper()
+
+	srv := httptest.NewServer(http.NotFoundHandler())
+	srv.Close()
+	return strings.TrimPrefix(srv.URL, "http://")
+}
+
+// failoverTransport Test Cases
+
+// TestFailoverToSecondary verifies that exports reach the secondary collector when the primary fails.
+// Test logic: Uses table-driven tests with a primary that answers 503 and one that can't be
+// reached. Exports two spans through a trace exporter built for both collectors and checks
+// each export succeeds, both spans reach the secondary, and the second export goes straight
+// to the secondary without trying the primary again.
+func TestFailoverToSecondary(t *testing.T) {
+	tests := []struct {
+		name    string
+		primary func(t *testing.T) (string, *outageCollector)
+	}{
+		{"unavailable", func(t *testing.T) (string, *outageCollector) {
+			c, endpoint := newOutageCollector(t)
+			c.down.Store(true)
+			return endpoint, c
+		}},
+		{"unreachable", func(t *testing.T) (string, *outageCollector) {
+			return closedEndpoint(t), nil
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			primaryEndpoint, primary := tt.primary(t)
+			secondary, secondaryEndpoint := newOutageCollector(t)
+			cfg := Config{
+				OTLPEndpoint:       primaryEndpoint,
+				OTLPEndpoints:      []string{secondaryEndpoint},
+				OTLPConnectTimeout: 5 * time.Second,
+			}
+
+			client, err := exportClient(cfg, "traces", []string{primaryEndpoint, secondaryEndpoint})
+			if err != nil {
+				t.Fatalf("exportClient() error = %v", err)
+			}
+			opts := append(traceExporterOptions(primaryEndpoint, cfg), otlptracehttp.WithHTTPClient(client))
+			exporter, err := otlptracehttp.New(context.Background(), opts...)
+			if err != nil {
+				t.Fatalf("failed to create exporter: %v", err)
+			}
+
+			for _, name := range []string{"first", "second"} {
+				if err := exporter.ExportSpans(context.Background(), tracetest.SpanStubs{{Name: name}}.Snapshots()); err != nil {
+					t.Fatalf("ExportSpans(%s) error = %v", name, err)
+				}
+			}
+
+			secondary.mu.Lock()
+			defer secondary.mu.Unlock()
+			if got := len(secondary.accepted); got != 2 {
+				t.Errorf("secondary accepted %d requests, want 2", got)
+			}
+			if primary != nil {
+				primary.mu.Lock()
+				defer primary.mu.Unlock()
+				if got := len(primary.refused); got != 1 {
+					t.Errorf("primary was tried %d times, want 1", got)
+				}
+			}
+		})
+	}
+}
+
+// TestExportClientDefault verifies that no custom client is built without failover or an offline buffer.
+// Test logic: Asks for the client of a single endpoint with no offline buffer and checks it is nil,
+// leaving the exporter's own client in place.
+func TestExportClientDefault(t *testing.T) {
+	client, err := exportClient(Config{}, "traces", []string{"localhost:4318"})
+	if err != nil || client != nil {
+		t.Errorf("exportClient() = %v, %v, want nil, nil", client, err)
+	}
+}
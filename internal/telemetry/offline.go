@@ -0,0 +1,309 @@
+package telemetry
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// defaultOfflineBufferMaxBytes bounds each signal's offline buffer when
+// Config.OfflineBufferMaxBytes is not set
+const defaultOfflineBufferMaxBytes = 64 << 20
+
+// replayBatchBytes bounds how much of the buffer one replay sends, so a
+// long outage drains over several exports instead of all at once
+const replayBatchBytes = 4 << 20
+
+// bufferedExt marks complete buffered requests. Files are written under a
+// temporary name first so a crash never leaves a partial request to replay.
+const bufferedExt = ".otlp"
+
+// offlineTransport is an http.RoundTripper for an OTLP exporter that buffers
+// export requests to disk while the collector is unreachable, and replays
+// them oldest first in the background after the next request that gets
+// through, up to batchBytes at a time. Buffered requests are reported to
+// the exporter as accepted, so they are not retried or dropped. The oldest
+// requests are evicted to keep the buffer under maxBytes.
+type offlineTransport struct {
+	next       http.RoundTripper
+	dir        string
+	maxBytes   int64
+	batchBytes int64         // Sent per replay before waiting for the next export
+	timeout    time.Duration // Per replayed request (0 for none)
+
+	mu  sync.Mutex // Serializes listing, writing and removing files
+	seq uint64     // Orders files written in the same nanosecond
+
+	replaying atomic.Bool    // A replay is running, so another won't start
+	replays   sync.WaitGroup // Replays in progress, for wait
+}
+
+// newOfflineTransport returns a transport for the signal's exporter
+// ("traces", "logs" or "metrics") that sends requests with next and
+// buffers to a subdirectory of cfg.OfflineBuffer
+func newOfflineTransport(cfg Config, signal string, next http.RoundTripper) (*offlineTransport, error) {
+	dir := filepath.Join(cfg.OfflineBuffer, signal)
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create offline buffer: %w", err)
+	}
+
+	maxBytes := cfg.OfflineBufferMaxBytes
+	if maxBytes <= 0 {
+		maxBytes = defaultOfflineBufferMaxBytes
+	}
+	return &offlineTransport{
+		next:       next,
+		dir:        dir,
+		maxBytes:   maxBytes,
+		batchBytes: replayBatchBytes,
+		timeout:    cfg.OTLPConnectTimeout,
+	}, nil
+}
+
+// RoundTrip sends the request, buffering it to disk if the collector can't
+// be reached or is unavailable
+func (t *offlineTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	body, err := readBody(req)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := t.next.RoundTrip(withBody(req, body))
+	if err == nil && !unavailable(resp.StatusCode) {
+		if resp.StatusCode >= 200 && resp.StatusCode <= 299 {
+			t.startReplay(req)
+		}
+		return resp, nil
+	}
+
+	if bufErr := t.store(body); bufErr != nil {
+		// Report the original failure so the exporter can handle it
+		if resp != nil {
+			return resp, nil
+		}
+		return nil, fmt.Errorf("%w (%w)", err, bufErr)
+	}
+	if resp != nil {
+		discard(resp)
+	}
+	return &http.Response{
+		Status:     "200 OK",
+		StatusCode: http.StatusOK,
+		Proto:      "HTTP/1.1",
+		ProtoMajor: 1,
+		ProtoMinor: 1,
+		Header:     make(http.Header),
+		Body:       http.NoBody,
+		Request:    req,
+	}, nil
+}
+
+// store writes a request body to the buffer, evicting the oldest requests
+// to make room
+func (t *offlineTransport) store(body []byte) error {
+	size := int64(len(body))
+	if size > t.maxBytes {
+		return fmt.Errorf("%d byte request is larger than the %d byte offline buffer", size, t.maxBytes)
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if err := t.evict(size); err != nil {
+		return err
+	}
+
+	t.seq++
+	name := fmt.Sprintf("%019d-%06d", time.Now().UnixNano(), t.seq%1_000_000)
+	tmp := filepath.Join(t.dir, name+".tmp")
+	if err := os.WriteFile(tmp, body, 0o600); err != nil {
+		return err
+	}
+	return os.Rename(tmp, filepath.Join(t.dir, name+bufferedExt))
+}
+
+// evict removes the oldest buffered requests until incoming more bytes fit.
+// Caller must hold t.mu.
+func (t *offlineTransport) evict(incoming int64) error {
+	files, err := t.files()
+	if err != nil {
+		return err
+	}
+
+	var total int64
+	sizes := make([]int64, len(files))
+	for i, name := range files {
+		info, err := os.Stat(filepath.Join(t.dir, name))
+		if err != nil {
+			return err
+		}
+		sizes[i] = info.Size()
+		total += sizes[i]
+	}
+
+	for i := 0; total+incoming > t.maxBytes && i < len(files); i++ {
+		if err := os.Remove(filepath.Join(t.dir, files[i])); err != nil {
+			return err
+		}
+		total -= sizes[i]
+	}
+	return nil
+}
+
+// startReplay replays the buffer in the background, unless a replay is
+// already running, so the live export that triggered it returns right away
+func (t *offlineTransport) startReplay(live *http.Request) {
+	if !t.replaying.CompareAndSwap(false, true) {
+		return
+	}
+	// Copy the request now; the exporter may reuse it once we return
+	live = live.Clone(context.Background())
+	t.replays.Add(1)
+	go func() {
+		defer t.replays.Done()
+		defer t.replaying.Store(false)
+		t.replay(live)
+	}()
+}
+
+// wait blocks until any replay in progress has finished or ctx is done,
+// returning ctx's error if a replay was still running
+func (t *offlineTransport) wait(ctx context.Context) error {
+	done := make(chan struct{})
+	go func() {
+		t.replays.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// replay sends buffered requests, oldest first, to the same place and with
+// the same headers as the live request that just got through. It stops once batchBytes have been
+// sent or at the first failure, leaving the rest for next time. The lock is
+// only held to list and remove files, never while sending, so exports and
+// new buffering aren't held up.
+func (t *offlineTransport) replay(live *http.Request) {
+	t.mu.Lock()
+	files, err := t.files()
+	t.mu.Unlock()
+	if err != nil {
+		return
+	}
+
+	var sent int64
+	for _, name := range files {
+		if t.batchBytes > 0 && sent >= t.batchBytes {
+			return
+		}
+		path := filepath.Join(t.dir, name)
+		body, err := os.ReadFile(path)
+		if errors.Is(err, os.ErrNotExist) {
+			// Evicted since it was listed
+			continue
+		}
+		if err != nil {
+			return
+		}
+
+		if !t.send(live, body) {
+			return
+		}
+		sent += int64(len(body))
+
+		// Delivered, or rejected for good; either way it's done
+		t.mu.Lock()
+		err = os.Remove(path)
+		t.mu.Unlock()
+		if err != nil && !errors.Is(err, os.ErrNotExist) {
+			return
+		}
+	}
+}
+
+// send posts one buffered request body like live, returning false if the
+// collector couldn't take it. Each send gets its own timeout since the live
+// request's context may already be done.
+func (t *offlineTransport) send(live *http.Request, body []byte) bool {
+	ctx := context.Background()
+	if t.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, t.timeout)
+		defer cancel()
+	}
+
+	resp, err := t.next.RoundTrip(withBody(live.WithContext(ctx), body))
+	if err != nil {
+		return false
+	}
+	discard(resp)
+	return !unavailable(resp.StatusCode)
+}
+
+// files returns the names of the buffered requests, oldest first
+func (t *offlineTransport) files() ([]string, error) {
+	entries, err := os.ReadDir(t.dir)
+	if err != nil {
+		return nil, err
+	}
+
+	var files []string
+	for _, e := range entries {
+		if !e.IsDir() && strings.HasSuffix(e.Name(), bufferedExt) {
+			files = append(files, e.Name())
+		}
+	}
+	return files, nil
+}
+
+// unavailable reports whether a status means the collector can't take
+// requests right now, the same statuses the exporters retry
+func unavailable(status int) bool {
+	switch status {
+	case http.StatusTooManyRequests,
+		http.StatusBadGateway,
+		http.StatusServiceUnavailable,
+		http.StatusGatewayTimeout:
+		return true
+	}
+	return false
+}
+
+// readBody reads and closes the request body
+func readBody(req *http.Request) ([]byte, error) {
+	if req.Body == nil {
+		return nil, nil
+	}
+	defer func() { _ = req.Body.Close() }()
+	return io.ReadAll(req.Body)
+}
+
+// withBody returns a copy of req that sends body
+func withBody(req *http.Request, body []byte) *http.Request {
+	r := req.Clone(req.Context())
+	r.Body = io.NopCloser(bytes.NewReader(body))
+	r.GetBody = func() (io.ReadCloser, error) {
+		return io.NopCloser(bytes.NewReader(body)), nil
+	}
+	r.ContentLength = int64(len(body))
+	return r
+}
+
+// discard reads and closes a response body so the connection can be reused
+func discard(resp *http.Response) {
+	_, _ = io.Copy(io.Discard, resp.Body)
+	_ = resp.Body.Close()
+}
@@ -0,0 +1,266 @@
+package telemetry
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+// outageCollector is a collector that can be taken down. It records every
+// request body it receives, answering 503 while down.
+type outageCollector struct {
+	down atomic.Bool
+
+	mu       sync.Mutex
+	accepted [][]byte // Bodies received while up
+	refused  [][]byte // Bodies received while down
+}
+
+// newOutageCollector starts an outageCollector and returns it with its host:port
+func newOutageCollector(t *testing.T) (*outageCollector, string) {
+	t.Helper()
+
+	c := &outageCollector{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		c.mu.Lock()
+		defer c.mu.Unlock()
+		if c.down.Load() {
+			c.refused = append(c.refused, body)
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		c.accepted = append(c.accepted, body)
+		w.WriteHeader(http.StatusOK)
+	}))
+	t.Cleanup(srv.Close)
+
+	return c, strings.TrimPrefix(srv.URL, "http://")
+}
+
+// bufferedFiles returns the names of the buffered requests in dir
+func bufferedFiles(t *testing.T, dir string) []string {
+	t.Helper()
+
+	files, err := (&offlineTransport{dir: dir}).files()
+	if err != nil {
+		t.Fatalf("failed to list buffer: %v", err)
+	}
+	return files
+}
+
+// offlineTransport Test Cases
+
+// TestOfflineBufferReplaysAfterRecovery verifies that exports made during an outage are sent once the collector recovers.
+// Test logic: Exports a span while the collector answers 503 and checks the export reports
+// success and one request is buffered on disk. Brings the collector back, exports a second
+// span, waits for the background replay, and checks the collector received the new request
+// and then the exact buffered one, leaving the buffer empty.
+func TestOfflineBufferReplaysAfterRecovery(t *testing.T) {
+	collector, endpoint := newOutageCollector(t)
+	cfg := Config{OfflineBuffer: t.TempDir(), OTLPConnectTimeout: 5 * time.Second}
+
+	client, err := exportClient(cfg, "traces", []string{endpoint})
+	if err != nil {
+		t.Fatalf("exportClient() error = %v", err)
+	}
+	opts := append(traceExporterOptions(endpoint, cfg), otlptracehttp.WithHTTPClient(client))
+	exporter, err := otlptracehttp.New(context.Background(), opts...)
+	if err != nil {
+		t.Fatalf("failed to create exporter: %v", err)
+	}
+	dir := filepath.Join(cfg.OfflineBuffer, "traces")
+
+	// During the outage the export is buffered instead of failing
+	collector.down.Store(true)
+	if err := exporter.ExportSpans(context.Background(), tracetest.SpanStubs{{Name: "offline"}}.Snapshots()); err != nil {
+		t.Fatalf("ExportSpans() during outage error = %v, want nil", err)
+	}
+	if got := len(bufferedFiles(t, dir)); got != 1 {
+		t.Fatalf("buffered %d requests during outage, want 1", got)
+	}
+
+	// The next export after recovery replays the buffered one
+	collector.down.Store(false)
+	if err := exporter.ExportSpans(context.Background(), tracetest.SpanStubs{{Name: "online"}}.Snapshots()); err != nil {
+		t.Fatalf("ExportSpans() after recovery error = %v", err)
+	}
+	client.Transport.(*offlineTransport).wait(context.Background())
+
+	collector.mu.Lock()
+	defer collector.mu.Unlock()
+	if len(collector.accepted) != 2 {
+		t.Fatalf("collector accepted %d requests, want 2", len(collector.accepted))
+	}
+	if !bytes.Equal(collector.accepted[1], collector.refused[0]) {
+		t.Error("replayed request does not match the one refused during the outage")
+	}
+	if files := bufferedFiles(t, dir); len(files) != 0 {
+		t.Errorf("buffer still holds %v after replay", files)
+	}
+}
+
+// TestOfflineBufferEvictsOldest verifies that the buffer stays under its size limit.
+// Test logic: Stores three 4 byte requests in a 10 byte buffer and checks only the two
+// newest remain, then checks a request larger than the whole buffer is refused.
+func TestOfflineBufferEvictsOldest(t *testing.T) {
+	tr := &offlineTransport{dir: t.TempDir(), maxBytes: 10}
+
+	for _, body := range []string{"aaaa", "bbbb", "cccc"} {
+		if err := tr.store([]byte(body)); err != nil {
+			t.Fatalf("store(%q) error = %v", body, err)
+		}
+	}
+
+	var got []string
+	for _, name := range bufferedFiles(t, tr.dir) {
+		body, err := os.ReadFile(filepath.Join(tr.dir, name))
+		if err != nil {
+			t.Fatal(err)
+		}
+		got = append(got, string(body))
+	}
+	if strings.Join(got, ",") != "bbbb,cccc" {
+		t.Errorf("buffer holds %v, want [bbbb cccc]", got)
+	}
+
+	// A request that can never fit is refused
+	if err := tr.store([]byte("too large to fit")); err == nil {
+		t.Error("store() of a request larger than the buffer error = nil, want error")
+	}
+}
+
+// TestOfflineBufferReplayInBackground verifies that a slow replay doesn't hold up live exports.
+// Test logic: Buffers a request, then sends a live one to a collector that stalls on the
+// buffered body. Checks the live request returns while the replay is still stalled, and a
+// second live request isn't blocked either. Once the collector is released the replay
+// finishes and empties the buffer.
+func TestOfflineBufferReplayInBackground(t *testing.T) {
+	stalled, release := make(chan struct{}), make(chan struct{})
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		if string(body) == "buffered" {
+			close(stalled)
+			<-release
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	t.Cleanup(srv.Close)
+
+	tr := &offlineTransport{next: http.DefaultTransport, dir: t.TempDir(), maxBytes: 1 << 10}
+	if err := tr.store([]byte("buffered")); err != nil {
+		t.Fatalf("store() error = %v", err)
+	}
+
+	post := func(body string) {
+		t.Helper()
+		req, _ := http.NewRequest(http.MethodPost, srv.URL, strings.NewReader(body))
+		resp, err := tr.RoundTrip(req)
+		if err != nil {
+			t.Fatalf("RoundTrip(%q) error = %v", body, err)
+		}
+		discard(resp)
+	}
+
+	// The live request comes back while its replay is stuck at the collector
+	post("live")
+	<-stalled
+	post("live again")
+
+	// Once the collector catches up the buffer drains
+	close(release)
+	tr.wait(context.Background())
+	if files := bufferedFiles(t, tr.dir); len(files) != 0 {
+		t.Errorf("buffer still holds %v after replay", files)
+	}
+}
+
+// TestWaitForReplaysDeadline verifies that shutdown waits for a replay only until its deadline.
+// Test logic: Starts a replay that stalls at the collector and checks waitForReplays gives up
+// with the context's deadline error, skipping a nil client. Once the collector is released,
+// checks waiting again returns nil with the buffer emptied.
+func TestWaitForReplaysDeadline(t *testing.T) {
+	stalled, release := make(chan struct{}), make(chan struct{})
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		if string(body) == "buffered" {
+			close(stalled)
+			<-release
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	t.Cleanup(srv.Close)
+
+	tr := &offlineTransport{next: http.DefaultTransport, dir: t.TempDir(), maxBytes: 1 << 10}
+	if err := tr.store([]byte("buffered")); err != nil {
+		t.Fatalf("store() error = %v", err)
+	}
+	client := &http.Client{Transport: tr}
+	resp, err := client.Post(srv.URL, "application/x-protobuf", strings.NewReader("live"))
+	if err != nil {
+		t.Fatalf("Post() error = %v", err)
+	}
+	discard(resp)
+	<-stalled
+
+	// The deadline passes while the replay is stuck
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if err := waitForReplays(ctx, nil, client); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("waitForReplays() error = %v, want context.DeadlineExceeded", err)
+	}
+
+	// Once the collector catches up the wait returns
+	close(release)
+	if err := waitForReplays(context.Background(), client); err != nil {
+		t.Errorf("waitForReplays() error = %v after the replay finished, want nil", err)
+	}
+	if files := bufferedFiles(t, tr.dir); len(files) != 0 {
+		t.Errorf("buffer still holds %v after replay", files)
+	}
+}
+
+// TestOfflineBufferReplayBatchLimit verifies that one replay sends at most a batch of the buffer.
+// Test logic: Buffers three 4 byte requests with an 8 byte batch, replays once, and checks
+// the two oldest were sent and the newest is left for the next replay, which sends it.
+func TestOfflineBufferReplayBatchLimit(t *testing.T) {
+	collector, endpoint := newOutageCollector(t)
+	tr := &offlineTransport{next: http.DefaultTransport, dir: t.TempDir(), maxBytes: 1 << 10, batchBytes: 8}
+	for _, body := range []string{"aaaa", "bbbb", "cccc"} {
+		if err := tr.store([]byte(body)); err != nil {
+			t.Fatalf("store(%q) error = %v", body, err)
+		}
+	}
+	live, _ := http.NewRequest(http.MethodPost, "http://"+endpoint, nil)
+
+	// The first replay stops at the batch size
+	tr.replay(live)
+	if files := bufferedFiles(t, tr.dir); len(files) != 1 {
+		t.Fatalf("buffer holds %d requests after one replay, want 1", len(files))
+	}
+
+	// The next replay picks up where it left off
+	tr.replay(live)
+	collector.mu.Lock()
+	defer collector.mu.Unlock()
+	var got []string
+	for _, body := range collector.accepted {
+		got = append(got, string(body))
+	}
+	if strings.Join(got, ",") != "aaaa,bbbb,cccc" {
+		t.Errorf("collector accepted %v, want [aaaa bbbb cccc]", got)
+	}
+}
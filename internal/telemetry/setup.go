@@ -0,0 +1,39 @@
+package telemetry
+
+import (
+	"context"
+	"log/slog"
+	"time"
+)
+
+// shutdownTimeout bounds how long cleanup waits for telemetry to flush
+const shutdownTimeout = 5 * time.Second
+
+// Setup starts OTel export in production and creates the logger, logging
+// the effective configuration. The returned function flushes telemetry and
+// closes the log file; call it once, when the program is done.
+func Setup(ctx context.Context, cfg Config) (*slog.Logger, func(), error) {
+	// Initialize OTel if in production
+	otelShutdown := func(context.Context) error { return nil }
+	if cfg.Environment == Production {
+		var err error
+		otelShutdown, err = InitOTel(ctx, cfg)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
+	// Create logger based on config (returns cleanup function for file handle)
+	logger, closeLog := NewLogger(cfg)
+
+	// Log the effective configuration (secrets are redacted)
+	logger.Info("configuration", "config", cfg)
+
+	return logger, func() {
+		_ = closeLog()
+		// Shutdown with fresh context (not the canceled one) to allow flushing
+		shutdownCtx, shutdownCancel := context.WithTimeout(context.Background(), shutdownTimeout)
+		defer shutdownCancel()
+		_ = otelShutdown(shutdownCtx)
+	}, nil
+}
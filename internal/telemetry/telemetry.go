@@ -5,7 +5,10 @@ import (
 	"fmt"
 	"io"
 	"log/slog"
+	"net/http"
+	"net/url"
 	"os"
+	"strconv"
 	"strings"
 
 	"go.opentelemetry.io/contrib/bridges/otelslog"
@@ -91,16 +94,17 @@ func (h *levelHandler) WithGroup(name string) slog.Handler {
 
 // InitOTel initializes OpenTelemetry tracing and logging, returns a shutdown function.
 // Call the shutdown function when the application exits to flush telemetry.
+// It also waits for any replay of the offline buffer, until ctx is done.
 func InitOTel(ctx context.Context, cfg Config) (func(context.Context) error, error) {
-	if cfg.OTLPEndpoint == "" {
+	if cfg.OTLPEndpoint == "" && cfg.OTLPLogEndpoint == "" {
 		// Return no-op shutdown if no endpoint configured
 		return func(context.Context) error { return nil }, nil
 	}
 
-	// Strip scheme from endpoint - WithEndpoint expects host:port only
-	endpoint := cfg.OTLPEndpoint
-	endpoint = strings.TrimPrefix(endpoint, "http://")
-	endpoint = strings.TrimPrefix(endpoint, "https://")
+	endpoints, err := resolveEndpoints(cfg)
+	if err != nil {
+		return nil, err
+	}
 
 	// Create resource with service name
 	res := resource.NewWithAttributes(
@@ -108,11 +112,44 @@ func InitOTel(ctx context.Context, cfg Config) (func(context.Context) error, err
 		semconv.ServiceName("megawave"),
 	)
 
+	failover, err := resolveFailoverEndpoints(cfg)
+	if err != nil {
+		return nil, err
+	}
+	traceHosts := append([]string{endpoints.traces}, failover...)
+	metricHosts := append([]string{endpoints.metrics}, failover...)
+	logHosts := []string{endpoints.logs}
+	if cfg.OTLPLogEndpoint == "" {
+		logHosts = append(logHosts, failover...)
+	}
+
+	traceOpts := traceExporterOptions(endpoints.traces, cfg)
+	logOpts := logExporterOptions(endpoints.logs, cfg)
+	metricOpts := metricExporterOptions(endpoints.metrics, cfg)
+	traceClient, err := exportClient(cfg, "traces", traceHosts)
+	if err != nil {
+		return nil, err
+	}
+	logClient, err := exportClient(cfg, "logs", logHosts)
+	if err != nil {
+		return nil, err
+	}
+	metricClient, err := exportClient(cfg, "metrics", metricHosts)
+	if err != nil {
+		return nil, err
+	}
+	if traceClient != nil {
+		traceOpts = append(traceOpts, otlptracehttp.WithHTTPClient(traceClient))
+	}
+	if logClient != nil {
+		logOpts = append(logOpts, otlploghttp.WithHTTPClient(logClient))
+	}
+	if metricClient != nil {
+		metricOpts = append(metricOpts, otlpmetrichttp.WithHTTPClient(metricClient))
+	}
+
 	// Create OTLP trace exporter
-	traceExporter, err := otlptracehttp.New(ctx,
-		otlptracehttp.WithEndpoint(endpoint),
-		otlptracehttp.WithInsecure(),
-	)
+	traceExporter, err := otlptracehttp.New(ctx, traceOpts...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create trace exporter: %w", err)
 	}
@@ -129,10 +166,7 @@ func InitOTel(ctx context.Context, cfg Config) (func(context.Context) error, err
 	))
 
 	// Create OTLP log exporter
-	logExporter, err := otlploghttp.New(ctx,
-		otlploghttp.WithEndpoint(endpoint),
-		otlploghttp.WithInsecure(),
-	)
+	logExporter, err := otlploghttp.New(ctx, logOpts...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create log exporter: %w", err)
 	}
@@ -145,10 +179,7 @@ func InitOTel(ctx context.Context, cfg Config) (func(context.Context) error, err
 	global.SetLoggerProvider(lp)
 
 	// Create OTLP metric exporter
-	metricExporter, err := otlpmetrichttp.New(ctx,
-		otlpmetrichttp.WithEndpoint(endpoint),
-		otlpmetrichttp.WithInsecure(),
-	)
+	metricExporter, err := otlpmetrichttp.New(ctx, metricOpts...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create metric exporter: %w", err)
 	}
@@ -157,6 +188,7 @@ func InitOTel(ctx context.Context, cfg Config) (func(context.Context) error, err
 	mp := sdkmetric.NewMeterProvider(
 		sdkmetric.WithReader(sdkmetric.NewPeriodicReader(metricExporter)),
 		sdkmetric.WithResource(res),
+		sdkmetric.WithView(metricViews(cfg)...),
 	)
 	otel.SetMeterProvider(mp)
 
@@ -172,9 +204,196 @@ func InitOTel(ctx context.Context, cfg Config) (func(context.Context) error, err
 		if err := mp.Shutdown(ctx); err != nil {
 			errs = append(errs, err)
 		}
+		// The final exports above may have started replays of the offline buffer
+		if err := waitForReplays(ctx, traceClient, logClient, metricClient); err != nil {
+			errs = append(errs, err)
+		}
 		if len(errs) > 0 {
 			return fmt.Errorf("shutdown errors: %v", errs)
 		}
 		return nil
 	}, nil
 }
+
+// otlpEndpoints holds the host:port each signal is exported to
+type otlpEndpoints struct {
+	traces  string
+	metrics string
+	logs    string
+}
+
+// resolveEndpoints normalizes the configured endpoints for each signal.
+// Logs go to OTLPLogEndpoint when set and to OTLPEndpoint otherwise; traces
+// and metrics always go to OTLPEndpoint, so it is required.
+func resolveEndpoints(cfg Config) (otlpEndpoints, error) {
+	if cfg.OTLPEndpoint == "" {
+		return otlpEndpoints{}, fmt.Errorf("OTLP log endpoint %q needs an OTLP endpoint for traces and metrics", cfg.OTLPLogEndpoint)
+	}
+
+	// WithEndpoint expects host:port only
+	endpoint, err := normalizeEndpoint(cfg.OTLPEndpoint)
+	if err != nil {
+		return otlpEndpoints{}, err
+	}
+	endpoints := otlpEndpoints{traces: endpoint, metrics: endpoint, logs: endpoint}
+
+	if cfg.OTLPLogEndpoint != "" {
+		endpoints.logs, err = normalizeEndpoint(cfg.OTLPLogEndpoint)
+		if err != nil {
+			return otlpEndpoints{}, err
+		}
+	}
+	return endpoints, nil
+}
+
+// resolveFailoverEndpoints normalizes cfg.OTLPEndpoints, the collectors
+// tried in order when OTLPEndpoint fails. They need OTLPEndpoint as the
+// primary.
+func resolveFailoverEndpoints(cfg Config) ([]string, error) {
+	if len(cfg.OTLPEndpoints) == 0 {
+		return nil, nil
+	}
+	if cfg.OTLPEndpoint == "" {
+		return nil, fmt.Errorf("OTLP failover endpoints %q need a primary OTLP endpoint", cfg.OTLPEndpoints)
+	}
+
+	failover := make([]string, len(cfg.OTLPEndpoints))
+	for i, endpoint := range cfg.OTLPEndpoints {
+		var err error
+		if failover[i], err = normalizeEndpoint(endpoint); err != nil {
+			return nil, err
+		}
+	}
+	return failover, nil
+}
+
+// exportClient returns the HTTP client for a signal's exporter, sending to
+// hosts (primary first) with failover when there are several, and through
+// the offline buffer when cfg.OfflineBuffer is set. Returns nil when
+// neither is needed and the exporter's own client will do.
+func exportClient(cfg Config, signal string, hosts []string) (*http.Client, error) {
+	if len(hosts) < 2 && cfg.OfflineBuffer == "" {
+		return nil, nil
+	}
+
+	var transport http.RoundTripper = http.DefaultTransport.(*http.Transport).Clone()
+	if len(hosts) > 1 {
+		transport = &failoverTransport{next: transport, hosts: hosts}
+	}
+	if cfg.OfflineBuffer != "" {
+		// Buffer only once every collector has failed
+		offline, err := newOfflineTransport(cfg, signal, transport)
+		if err != nil {
+			return nil, err
+		}
+		transport = offline
+	}
+	return &http.Client{Transport: transport, Timeout: cfg.OTLPConnectTimeout}, nil
+}
+
+// waitForReplays waits for the offline buffers behind clients to finish any
+// replay in progress, until ctx is done. Clients without one are skipped.
+// Requests a replay didn't get to stay buffered for the next run.
+func waitForReplays(ctx context.Context, clients ...*http.Client) error {
+	for _, client := range clients {
+		if client == nil {
+			continue
+		}
+		if offline, ok := client.Transport.(*offlineTransport); ok {
+			if err := offline.wait(ctx); err != nil {
+				return fmt.Errorf("offline buffer replay unfinished: %w", err)
+			}
+		}
+	}
+	return nil
+}
+
+// normalizeEndpoint validates an OTLP endpoint and reduces it to host:port.
+// An http:// or https:// scheme, path, and trailing slash are stripped.
+func normalizeEndpoint(endpoint string) (string, error) {
+	raw := endpoint
+	if !strings.Contains(raw, "://") {
+		raw = "http://" + raw
+	}
+
+	u, err := url.Parse(raw)
+	if err != nil {
+		return "", fmt.Errorf("invalid OTLP endpoint %q: %w", endpoint, err)
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return "", fmt.Errorf("invalid OTLP endpoint %q: unsupported scheme %q", endpoint, u.Scheme)
+	}
+	if u.Hostname() == "" {
+		return "", fmt.Errorf("invalid OTLP endpoint %q: missing host", endpoint)
+	}
+	port, err := strconv.Atoi(u.Port())
+	if err != nil || port < 1 || port > 65535 {
+		return "", fmt.Errorf("invalid OTLP endpoint %q: expected host:port", endpoint)
+	}
+
+	return u.Host, nil
+}
+
+// metricViews builds the metric views from the config. HistogramBuckets
+// replaces the bucket boundaries of every histogram.
+func metricViews(cfg Config) []sdkmetric.View {
+	if len(cfg.HistogramBuckets) == 0 {
+		return nil
+	}
+	return []sdkmetric.View{
+		sdkmetric.NewView(
+			sdkmetric.Instrument{Kind: sdkmetric.InstrumentKindHistogram},
+			sdkmetric.Stream{Aggregation: sdkmetric.AggregationExplicitBucketHistogram{
+				Boundaries: cfg.HistogramBuckets,
+			}},
+		),
+	}
+}
+
+// traceExporterOptions builds the OTLP trace exporter options from the config
+func traceExporterOptions(endpoint string, cfg Config) []otlptracehttp.Option {
+	opts := []otlptracehttp.Option{
+		otlptracehttp.WithEndpoint(endpoint),
+		otlptracehttp.WithInsecure(),
+		otlptracehttp.WithRetry(otlptracehttp.RetryConfig(cfg.OTLPRetry)),
+	}
+	if len(cfg.OTLPHeaders) > 0 {
+		opts = append(opts, otlptracehttp.WithHeaders(cfg.OTLPHeaders))
+	}
+	if cfg.OTLPConnectTimeout > 0 {
+		opts = append(opts, otlptracehttp.WithTimeout(cfg.OTLPConnectTimeout))
+	}
+	return opts
+}
+
+// logExporterOptions builds the OTLP log exporter options from the config
+func logExporterOptions(endpoint string, cfg Config) []otlploghttp.Option {
+	opts := []otlploghttp.Option{
+		otlploghttp.WithEndpoint(endpoint),
+		otlploghttp.WithInsecure(),
+		otlploghttp.WithRetry(otlploghttp.RetryConfig(cfg.OTLPRetry)),
+	}
+	if len(cfg.OTLPHeaders) > 0 {
+		opts = append(opts, otlploghttp.WithHeaders(cfg.OTLPHeaders))
+	}
+	if cfg.OTLPConnectTimeout > 0 {
+		opts = append(opts, otlploghttp.WithTimeout(cfg.OTLPConnectTimeout))
+	}
+	return opts
+}
+
+// metricExporterOptions builds the OTLP metric exporter options from the config
+func metricExporterOptions(endpoint string, cfg Config) []otlpmetrichttp.Option {
+	opts := []otlpmetrichttp.Option{
+		otlpmetrichttp.WithEndpoint(endpoint),
+		otlpmetrichttp.WithInsecure(),
+		otlpmetrichttp.WithRetry(otlpmetrichttp.RetryConfig(cfg.OTLPRetry)),
+	}
+	if len(cfg.OTLPHeaders) > 0 {
+		opts = append(opts, otlpmetrichttp.WithHeaders(cfg.OTLPHeaders))
+	}
+	if cfg.OTLPConnectTimeout > 0 {
+		opts = append(opts, otlpmetrichttp.WithTimeout(cfg.OTLPConnectTimeout))
+	}
+	return opts
+}
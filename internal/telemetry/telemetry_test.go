@@ -0,0 +1,306 @@
+package telemetry
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"go.opentelemetry.io/otel/exporters/otlp/otlplog/otlploghttp"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	otellog "go.opentelemetry.io/otel/log"
+	sdklog "go.opentelemetry.io/otel/sdk/log"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+// newFlakyCollector starts a server that answers 503 to the first request and 200 afterwards.
+// Returns the server's host:port and a counter of received requests.
+func newFlakyCollector(t *testing.T) (string, *atomic.Int32) {
+	t.Helper()
+
+	var requests atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if requests.Add(1) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	t.Cleanup(srv.Close)
+
+	return strings.TrimPrefix(srv.URL, "http://"), &requests
+}
+
+// testSpans returns a single finished span to export
+func testSpans() []sdktrace.ReadOnlySpan {
+	return tracetest.SpanStubs{{Name: "test"}}.Snapshots()
+}
+
+// normalizeEndpoint Test Cases
+
+// TestNormalizeEndpoint verifies that valid endpoints are reduced to host:port.
+// Test logic: Uses table-driven tests to normalize endpoints with and without schemes,
+// paths, and trailing slashes and checks the resulting host:port.
+func TestNormalizeEndpoint(t *testing.T) {
+	tests := []struct {
+		endpoint string
+		expected string
+	}{
+		{"localhost:4318", "localhost:4318"},
+		{"http://localhost:4318", "localhost:4318"},
+		{"http://localhost:4318/", "localhost:4318"},
+		{"https://collector.example.com:4318/v1/traces", "collector.example.com:4318"},
+		{"[::1]:4318", "[::1]:4318"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.endpoint, func(t *testing.T) {
+			got, err := normalizeEndpoint(tt.endpoint)
+			if err != nil {
+				t.Fatalf("normalizeEndpoint(%q) error = %v", tt.endpoint, err)
+			}
+			if got != tt.expected {
+				t.Errorf("normalizeEndpoint(%q) = %q, want %q", tt.endpoint, got, tt.expected)
+			}
+		})
+	}
+}
+
+// TestNormalizeEndpointInvalid verifies that malformed endpoints are rejected.
+// Test logic: Uses table-driven tests to normalize invalid endpoints and checks each
+// returns an error mentioning the endpoint.
+func TestNormalizeEndpointInvalid(t *testing.T) {
+	tests := []string{
+		"localhost",
+		"http://",
+		":4318",
+		"localhost:abc",
+		"localhost:0",
+		"localhost:70000",
+		"grpc://localhost:4317",
+	}
+
+	for _, endpoint := range tests {
+		t.Run(endpoint, func(t *testing.T) {
+			_, err := normalizeEndpoint(endpoint)
+			if err == nil {
+				t.Fatalf("normalizeEndpoint(%q) error = nil, want error", endpoint)
+			}
+			if !strings.Contains(err.Error(), endpoint) {
+				t.Errorf("error %q should mention the endpoint %q", err, endpoint)
+			}
+		})
+	}
+}
+
+// newCountingCollector starts a server that accepts every request.
+// Returns the server's host:port and a counter of received requests.
+func newCountingCollector(t *testing.T) (string, *atomic.Int32) {
+	t.Helper()
+
+	var requests atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		w.WriteHeader(http.StatusOK)
+	}))
+	t.Cleanup(srv.Close)
+
+	return strings.TrimPrefix(srv.URL, "http://"), &requests
+}
+
+// resolveEndpoints Test Cases
+
+// TestResolveEndpoints verifies that logs use the log endpoint when set and fall back otherwise.
+// Test logic: Uses table-driven tests to resolve configs with and without OTLPLogEndpoint
+// and checks the normalized endpoint chosen for each signal.
+func TestResolveEndpoints(t *testing.T) {
+	tests := []struct {
+		name     string
+		cfg      Config
+		expected otlpEndpoints
+	}{
+		{
+			name:     "shared endpoint",
+			cfg:      Config{OTLPEndpoint: "localhost:4318"},
+			expected: otlpEndpoints{traces: "localhost:4318", metrics: "localhost:4318", logs: "localhost:4318"},
+		},
+		{
+			name:     "separate log endpoint",
+			cfg:      Config{OTLPEndpoint: "localhost:4318", OTLPLogEndpoint: "http://logs.example.com:4318/"},
+			expected: otlpEndpoints{traces: "localhost:4318", metrics: "localhost:4318", logs: "logs.example.com:4318"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := resolveEndpoints(tt.cfg)
+			if err != nil {
+				t.Fatalf("resolveEndpoints() error = %v", err)
+			}
+			if got != tt.expected {
+				t.Errorf("resolveEndpoints() = %+v, want %+v", got, tt.expected)
+			}
+		})
+	}
+}
+
+// TestResolveEndpointsInvalid verifies that a bad or unusable log endpoint is rejected.
+// Test logic: Resolves a config with an invalid log endpoint and one with only a log
+// endpoint, and checks both return an error mentioning the log endpoint.
+func TestResolveEndpointsInvalid(t *testing.T) {
+	tests := []Config{
+		{OTLPEndpoint: "localhost:4318", OTLPLogEndpoint: "logs"},
+		{OTLPLogEndpoint: "logs:4318"},
+	}
+
+	for _, cfg := range tests {
+		t.Run(cfg.OTLPLogEndpoint, func(t *testing.T) {
+			_, err := resolveEndpoints(cfg)
+			if err == nil {
+				t.Fatal("resolveEndpoints() error = nil, want error")
+			}
+			if !strings.Contains(err.Error(), cfg.OTLPLogEndpoint) {
+				t.Errorf("error %q should mention the log endpoint %q", err, cfg.OTLPLogEndpoint)
+			}
+		})
+	}
+}
+
+// TestLogExporterUsesLogEndpoint verifies that logs are exported to the log-specific endpoint.
+// Test logic: Starts a main collector and a log collector, resolves a config naming both,
+// exports a log record with the resolved log exporter options, and checks only the log
+// collector received it.
+func TestLogExporterUsesLogEndpoint(t *testing.T) {
+	mainEndpoint, mainRequests := newCountingCollector(t)
+	logEndpoint, logRequests := newCountingCollector(t)
+	cfg := Config{
+		OTLPEndpoint:       mainEndpoint,
+		OTLPLogEndpoint:    logEndpoint,
+		OTLPConnectTimeout: 5 * time.Second,
+	}
+
+	endpoints, err := resolveEndpoints(cfg)
+	if err != nil {
+		t.Fatalf("resolveEndpoints() error = %v", err)
+	}
+	exporter, err := otlploghttp.New(context.Background(), logExporterOptions(endpoints.logs, cfg)...)
+	if err != nil {
+		t.Fatalf("failed to create exporter: %v", err)
+	}
+
+	var record sdklog.Record
+	record.SetBody(otellog.StringValue("hello"))
+	if err := exporter.Export(context.Background(), []sdklog.Record{record}); err != nil {
+		t.Fatalf("Export() error = %v", err)
+	}
+
+	if got := logRequests.Load(); got != 1 {
+		t.Errorf("log collector received %d requests, want 1", got)
+	}
+	if got := mainRequests.Load(); got != 0 {
+		t.Errorf("main collector received %d requests, want 0", got)
+	}
+}
+
+// metricViews Test Cases
+
+// TestMetricViewsHistogramBuckets verifies that HistogramBuckets sets the histogram boundaries.
+// Test logic: Builds a meter provider with the views for configured buckets and one without,
+// records the microwave.remaining_at_cancel histogram on each, and checks the collected
+// bounds are the configured ones and the SDK defaults respectively.
+func TestMetricViewsHistogramBuckets(t *testing.T) {
+	tests := []struct {
+		name    string
+		buckets []float64
+		want    []float64
+	}{
+		{"configured", []float64{5, 30, 60, 300}, []float64{5, 30, 60, 300}},
+		{"default", nil, []float64{0, 5, 10, 25, 50, 75, 100, 250, 500, 750, 1000, 2500, 5000, 7500, 10000}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reader := sdkmetric.NewManualReader()
+			mp := sdkmetric.NewMeterProvider(
+				sdkmetric.WithReader(reader),
+				sdkmetric.WithView(metricViews(Config{HistogramBuckets: tt.buckets})...),
+			)
+			histogram, err := mp.Meter("test").Int64Histogram("microwave.remaining_at_cancel")
+			if err != nil {
+				t.Fatalf("failed to create histogram: %v", err)
+			}
+			histogram.Record(context.Background(), 42)
+
+			var rm metricdata.ResourceMetrics
+			if err := reader.Collect(context.Background(), &rm); err != nil {
+				t.Fatalf("failed to collect metrics: %v", err)
+			}
+			data, ok := rm.ScopeMetrics[0].Metrics[0].Data.(metricdata.Histogram[int64])
+			if !ok || len(data.DataPoints) != 1 {
+				t.Fatalf("unexpected histogram data: %+v", rm.ScopeMetrics[0].Metrics[0].Data)
+			}
+			if got := data.DataPoints[0].Bounds; !slices.Equal(got, tt.want) {
+				t.Errorf("Bounds = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+// traceExporterOptions Test Cases
+
+// TestTraceExporterOptionsRetryEnabled verifies that the trace exporter retries a failed export.
+// Test logic: Exports a span to a collector that fails the first request with retry enabled,
+// and checks the export succeeds after a second request.
+func TestTraceExporterOptionsRetryEnabled(t *testing.T) {
+	endpoint, requests := newFlakyCollector(t)
+	cfg := Config{
+		OTLPConnectTimeout: 5 * time.Second,
+		OTLPRetry: RetryConfig{
+			Enabled:         true,
+			InitialInterval: 10 * time.Millisecond,
+			MaxInterval:     10 * time.Millisecond,
+			MaxElapsedTime:  time.Second,
+		},
+	}
+
+	exporter, err := otlptracehttp.New(context.Background(), traceExporterOptions(endpoint, cfg)...)
+	if err != nil {
+		t.Fatalf("failed to create exporter: %v", err)
+	}
+
+	// Export should succeed once the retry reaches the recovered collector
+	if err := exporter.ExportSpans(context.Background(), testSpans()); err != nil {
+		t.Errorf("ExportSpans() error = %v, want nil", err)
+	}
+	if got := requests.Load(); got != 2 {
+		t.Errorf("collector received %d requests, want 2", got)
+	}
+}
+
+// TestTraceExporterOptionsRetryDisabled verifies that the trace exporter does not retry when disabled.
+// Test logic: Exports a span to a collector that fails the first request with retry disabled,
+// and checks the export fails after a single request.
+func TestTraceExporterOptionsRetryDisabled(t *testing.T) {
+	endpoint, requests := newFlakyCollector(t)
+	cfg := Config{OTLPConnectTimeout: 5 * time.Second}
+
+	exporter, err := otlptracehttp.New(context.Background(), traceExporterOptions(endpoint, cfg)...)
+	if err != nil {
+		t.Fatalf("failed to create exporter: %v", err)
+	}
+
+	// Export should fail on the first 503
+	if err := exporter.ExportSpans(context.Background(), testSpans()); err == nil {
+		t.Error("ExportSpans() error = nil, want error without retry")
+	}
+	if got := requests.Load(); got != 1 {
+		t.Errorf("collector received %d requests, want 1", got)
+	}
+}
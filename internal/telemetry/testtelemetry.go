@@ -0,0 +1,80 @@
+package telemetry
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"sync"
+
+	"go.opentelemetry.io/otel/metric"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// TestTelemetry captures logs, spans and metrics in memory so a test can
+// assert on all three signals with one setup. Pass Logger, Tracer and Meter
+// to the code under test, then read Logs, Spans and Collect.
+type TestTelemetry struct {
+	Logger *slog.Logger // Writes JSON lines at debug level and above
+	Tracer trace.Tracer // Exports ended spans synchronously to Spans
+	Meter  metric.Meter // Read with Collect
+
+	Spans   *tracetest.InMemoryExporter
+	Metrics *sdkmetric.ManualReader
+
+	logs *syncBuffer
+}
+
+// NewTestTelemetry creates an in-memory telemetry bundle for tests. Unlike
+// NewLogger in the Test environment, nothing is discarded.
+func NewTestTelemetry() *TestTelemetry {
+	logs := &syncBuffer{}
+	spans := tracetest.NewInMemoryExporter()
+	reader := sdkmetric.NewManualReader()
+
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSyncer(spans))
+	mp := sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))
+
+	return &TestTelemetry{
+		Logger:  slog.New(slog.NewJSONHandler(logs, &slog.HandlerOptions{Level: slog.LevelDebug})),
+		Tracer:  tp.Tracer("megawave"),
+		Meter:   mp.Meter("megawave"),
+		Spans:   spans,
+		Metrics: reader,
+		logs:    logs,
+	}
+}
+
+// Logs returns the JSON log lines written so far
+func (t *TestTelemetry) Logs() string {
+	return t.logs.String()
+}
+
+// Collect reads the current value of every metric recorded through Meter
+func (t *TestTelemetry) Collect(ctx context.Context) (metricdata.ResourceMetrics, error) {
+	var rm metricdata.ResourceMetrics
+	err := t.Metrics.Collect(ctx, &rm)
+	return rm, err
+}
+
+// syncBuffer is a bytes.Buffer that can be written by the code under test
+// while the test reads it
+type syncBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *syncBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Write(p)
+}
+
+func (b *syncBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.String()
+}